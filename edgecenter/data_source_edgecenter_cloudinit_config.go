@@ -0,0 +1,204 @@
+package edgecenter
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"log"
+	"mime/multipart"
+	"net/textproto"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// UserDataMaxSize is the platform limit of the base64-encoded user data of an instance.
+	UserDataMaxSize = 65535
+
+	cloudInitDefaultBoundary = "MIMEBOUNDARY"
+	cloudInitCloudConfig     = "text/cloud-config"
+)
+
+func dataSourceCloudInitConfig() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceCloudInitConfigRead,
+		Description: `Renders a multipart cloud-init document from typed parts, ready for 'user_data' of instances.
+The data source works offline and checks the size of the document against the platform limit at plan time.`,
+		Schema: map[string]*schema.Schema{
+			"part": {
+				Type:        schema.TypeList,
+				Required:    true,
+				MinItems:    1,
+				Description: "The parts of the document, in the order cloud-init processes them.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"content_type": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Default:  "text/plain",
+							Description: `The MIME type of the part, for example 'text/x-shellscript' or 'text/cloud-config'.
+Cloud-config parts must be valid YAML mappings.`,
+							ValidateFunc: validation.StringMatch(regexp.MustCompile(`^[a-z0-9.+-]+/[a-z0-9.+-]+$`), "must be a MIME type"),
+						},
+						"content": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The body of the part.",
+						},
+						"filename": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The file name of the part, shown in the cloud-init logs.",
+						},
+						"merge_type": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "How cloud-init merges the part with the previous ones, for example 'list(append)+dict(recurse_array)+str()'.",
+						},
+					},
+				},
+			},
+			"gzip": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Set to true to compress the document. A compressed document is always base64-encoded.",
+			},
+			"base64_encode": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Description: `Set to true to base64-encode the document. Instances encode 'user_data' themselves,
+so it is only needed for 'gzip' and for resources which expect encoded user data, like edgecenter_baremetal.`,
+			},
+			"boundary": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     cloudInitDefaultBoundary,
+				Description: "The boundary between the parts of the document.",
+			},
+			"rendered": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The rendered document.",
+			},
+			"size": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: fmt.Sprintf("The size of the base64-encoded document, which may not exceed %d bytes.", UserDataMaxSize),
+			},
+		},
+	}
+}
+
+func dataSourceCloudInitConfigRead(_ context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	log.Println("[DEBUG] Start CloudInitConfig reading")
+
+	if d.Get("gzip").(bool) && !d.Get("base64_encode").(bool) {
+		return diag.Errorf("a gzip document must be base64-encoded, set base64_encode to true")
+	}
+
+	document, err := renderCloudInitConfig(d.Get("part").([]interface{}), d.Get("boundary").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if d.Get("gzip").(bool) {
+		var buf bytes.Buffer
+		w := gzip.NewWriter(&buf)
+		if _, err := w.Write(document); err != nil {
+			return diag.FromErr(err)
+		}
+		if err := w.Close(); err != nil {
+			return diag.FromErr(err)
+		}
+		document = buf.Bytes()
+	}
+
+	encoded := base64.StdEncoding.EncodeToString(document)
+	if len(encoded) > UserDataMaxSize {
+		return diag.Errorf("the base64-encoded document is %d bytes, which exceeds the user data limit of %d bytes, consider setting gzip to true", len(encoded), UserDataMaxSize)
+	}
+
+	rendered := string(document)
+	if d.Get("base64_encode").(bool) {
+		rendered = encoded
+	}
+
+	d.SetId(strconv.Itoa(schema.HashString(rendered)))
+	d.Set("rendered", rendered)
+	d.Set("size", len(encoded))
+
+	log.Println("[DEBUG] Finish CloudInitConfig reading")
+
+	return nil
+}
+
+// renderCloudInitConfig builds the MIME multipart document of the parts.
+func renderCloudInitConfig(parts []interface{}, boundary string) ([]byte, error) {
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+	if err := w.SetBoundary(boundary); err != nil {
+		return nil, fmt.Errorf("invalid boundary %q: %w", boundary, err)
+	}
+
+	fmt.Fprintf(&buf, "Content-Type: multipart/mixed; boundary=%q\r\nMIME-Version: 1.0\r\n\r\n", boundary)
+	for i, raw := range parts {
+		part := raw.(map[string]interface{})
+		contentType := part["content_type"].(string)
+		content := part["content"].(string)
+
+		if contentType == cloudInitCloudConfig {
+			if err := validateCloudConfig(content); err != nil {
+				return nil, fmt.Errorf("part %d is not a valid cloud-config: %w", i, err)
+			}
+		}
+
+		header := textproto.MIMEHeader{}
+		header.Set("Content-Type", contentType)
+		header.Set("MIME-Version", "1.0")
+		header.Set("Content-Transfer-Encoding", "7bit")
+		if filename := part["filename"].(string); filename != "" {
+			header.Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+		}
+		if mergeType := part["merge_type"].(string); mergeType != "" {
+			header.Set("X-Merge-Type", mergeType)
+		}
+
+		pw, err := w.CreatePart(header)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := pw.Write([]byte(content)); err != nil {
+			return nil, err
+		}
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// validateCloudConfig checks that the content is a YAML mapping, optionally preceded by the #cloud-config header.
+func validateCloudConfig(content string) error {
+	if strings.TrimSpace(content) == "" {
+		return fmt.Errorf("the content is empty")
+	}
+
+	var config interface{}
+	if err := yaml.Unmarshal([]byte(content), &config); err != nil {
+		return err
+	}
+	if _, ok := config.(map[string]interface{}); !ok {
+		return fmt.Errorf("the content must be a YAML mapping")
+	}
+
+	return nil
+}
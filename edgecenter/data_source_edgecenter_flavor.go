@@ -0,0 +1,72 @@
+package edgecenter
+
+import (
+	"context"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceFlavor() *schema.Resource {
+	s := flavorFilterSchema()
+	for k, v := range flavorSchema() {
+		s[k] = v
+	}
+	s["flavor_id"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Computed:    true,
+		Description: "The ID of the flavor, for example 'g1-standard-2-4'.",
+	}
+	s["flavor_name"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Computed:    true,
+		Description: "The name of the flavor, case-insensitive.",
+	}
+
+	return &schema.Resource{
+		ReadContext: dataSourceFlavorRead,
+		Description: `Finds a flavor of virtual machines or baremetal servers available in the project and region.
+When several flavors match the filters, the cheapest one is returned, the smallest among equally priced ones.
+Flavors without a price come after the priced ones. The flavor_id of resources is not validated at plan time,
+use this data source to pick one available in the region.`,
+		Schema: s,
+	}
+}
+
+func dataSourceFlavorRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Println("[DEBUG] Start Flavor reading")
+	config := m.(*Config)
+	flavorID := d.Get("flavor_id").(string)
+	flavorName := d.Get("flavor_name").(string)
+
+	found, err := listFilteredFlavors(config, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var flavor *flavorInfo
+	for i, f := range found {
+		if (flavorID == "" || f.FlavorID == flavorID) && (flavorName == "" || strings.EqualFold(f.FlavorName, flavorName)) {
+			flavor = &found[i]
+			break
+		}
+	}
+	if flavor == nil {
+		return diag.Errorf("flavor matching the filters not found")
+	}
+
+	d.SetId(flavor.FlavorID)
+	for k, v := range flavorToMap(*flavor) {
+		if err := d.Set(k, v); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	log.Println("[DEBUG] Finish Flavor reading")
+
+	return nil
+}
@@ -0,0 +1,49 @@
+package edgecenter
+
+import (
+	"context"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceFlavors() *schema.Resource {
+	s := flavorFilterSchema()
+	s["flavors"] = &schema.Schema{
+		Type:        schema.TypeList,
+		Computed:    true,
+		Description: "The flavors matching the filters, from the cheapest and the smallest among equally priced ones. Flavors without a price come last.",
+		Elem:        &schema.Resource{Schema: flavorSchema()},
+	}
+
+	return &schema.Resource{
+		ReadContext: dataSourceFlavorsRead,
+		Description: "Lists the flavors of virtual machines or baremetal servers available in the project and region.",
+		Schema:      s,
+	}
+}
+
+func dataSourceFlavorsRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Println("[DEBUG] Start Flavors reading")
+	config := m.(*Config)
+
+	found, err := listFilteredFlavors(config, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	result := make([]map[string]interface{}, 0, len(found))
+	for _, f := range found {
+		result = append(result, flavorToMap(f))
+	}
+
+	d.SetId(flavorIDsHash(found))
+	if err := d.Set("flavors", result); err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Println("[DEBUG] Finish Flavors reading")
+
+	return nil
+}
@@ -22,28 +22,28 @@ allowing it to have a static public IP address. The floating IP can be re-associ
 
 		Schema: map[string]*schema.Schema{
 			"project_id": {
-				Type:         schema.TypeInt,
-				Optional:     true,
-				Description:  "The uuid of the project. Either 'project_id' or 'project_name' must be specified.",
-				ExactlyOneOf: []string{"project_id", "project_name"},
+				Type:          schema.TypeInt,
+				Optional:      true,
+				Description:   "The ID of the project. Either 'project_id' or 'project_name' must be specified, unless the provider sets a default project.",
+				ConflictsWith: []string{"project_name"},
 			},
 			"project_name": {
-				Type:         schema.TypeString,
-				Optional:     true,
-				Description:  "The name of the project. Either 'project_id' or 'project_name' must be specified.",
-				ExactlyOneOf: []string{"project_id", "project_name"},
+				Type:          schema.TypeString,
+				Optional:      true,
+				Description:   "The name of the project. Either 'project_id' or 'project_name' must be specified, unless the provider sets a default project.",
+				ConflictsWith: []string{"project_id"},
 			},
 			"region_id": {
-				Type:         schema.TypeInt,
-				Optional:     true,
-				Description:  "The uuid of the region. Either 'region_id' or 'region_name' must be specified.",
-				ExactlyOneOf: []string{"region_id", "region_name"},
+				Type:          schema.TypeInt,
+				Optional:      true,
+				Description:   "The ID of the region. Either 'region_id' or 'region_name' must be specified, unless the provider sets a default region.",
+				ConflictsWith: []string{"region_name"},
 			},
 			"region_name": {
-				Type:         schema.TypeString,
-				Optional:     true,
-				Description:  "The name of the region. Either 'region_id' or 'region_name' must be specified.",
-				ExactlyOneOf: []string{"region_id", "region_name"},
+				Type:          schema.TypeString,
+				Optional:      true,
+				Description:   "The name of the region. Either 'region_id' or 'region_name' must be specified, unless the provider sets a default region.",
+				ConflictsWith: []string{"region_id"},
 			},
 			"floating_ip_address": {
 				Type:        schema.TypeString,
@@ -121,9 +121,8 @@ func dataSourceFloatingIPRead(_ context.Context, d *schema.ResourceData, m inter
 	log.Println("[DEBUG] Start FloatingIP reading")
 	var diags diag.Diagnostics
 	config := m.(*Config)
-	provider := config.Provider
 
-	client, err := CreateClient(provider, d, FloatingIPsPoint, VersionPointV1)
+	client, err := CreateClient(config, d, FloatingIPsPoint, VersionPointV1)
 	if err != nil {
 		return diag.FromErr(err)
 	}
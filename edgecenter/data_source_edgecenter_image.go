@@ -22,28 +22,28 @@ func dataSourceImage() *schema.Resource {
 		Description: "A cloud image is a pre-configured virtual machine template that you can use to create new instances.",
 		Schema: map[string]*schema.Schema{
 			"project_id": {
-				Type:         schema.TypeInt,
-				Optional:     true,
-				Description:  "The uuid of the project. Either 'project_id' or 'project_name' must be specified.",
-				ExactlyOneOf: []string{"project_id", "project_name"},
+				Type:          schema.TypeInt,
+				Optional:      true,
+				Description:   "The ID of the project. Either 'project_id' or 'project_name' must be specified, unless the provider sets a default project.",
+				ConflictsWith: []string{"project_name"},
 			},
 			"project_name": {
-				Type:         schema.TypeString,
-				Optional:     true,
-				Description:  "The name of the project. Either 'project_id' or 'project_name' must be specified.",
-				ExactlyOneOf: []string{"project_id", "project_name"},
+				Type:          schema.TypeString,
+				Optional:      true,
+				Description:   "The name of the project. Either 'project_id' or 'project_name' must be specified, unless the provider sets a default project.",
+				ConflictsWith: []string{"project_id"},
 			},
 			"region_id": {
-				Type:         schema.TypeInt,
-				Optional:     true,
-				Description:  "The uuid of the region. Either 'region_id' or 'region_name' must be specified.",
-				ExactlyOneOf: []string{"region_id", "region_name"},
+				Type:          schema.TypeInt,
+				Optional:      true,
+				Description:   "The ID of the region. Either 'region_id' or 'region_name' must be specified, unless the provider sets a default region.",
+				ConflictsWith: []string{"region_name"},
 			},
 			"region_name": {
-				Type:         schema.TypeString,
-				Optional:     true,
-				Description:  "The name of the region. Either 'region_id' or 'region_name' must be specified.",
-				ExactlyOneOf: []string{"region_id", "region_name"},
+				Type:          schema.TypeString,
+				Optional:      true,
+				Description:   "The name of the region. Either 'region_id' or 'region_name' must be specified, unless the provider sets a default region.",
+				ConflictsWith: []string{"region_id"},
 			},
 			"name": {
 				Type:        schema.TypeString,
@@ -123,13 +123,12 @@ func dataSourceImageRead(_ context.Context, d *schema.ResourceData, m interface{
 	name := d.Get("name").(string)
 
 	config := m.(*Config)
-	provider := config.Provider
 
 	point := ImagesPoint
 	if isBm, _ := d.Get("is_baremetal").(bool); isBm {
 		point = bmImagesPoint
 	}
-	client, err := CreateClient(provider, d, point, VersionPointV1)
+	client, err := CreateClient(config, d, point, VersionPointV1)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -18,28 +18,28 @@ func dataSourceInstance() *schema.Resource {
 		Description: `A cloud instance is a virtual machine in a cloud environment. Could be used with baremetal also.`,
 		Schema: map[string]*schema.Schema{
 			"project_id": {
-				Type:         schema.TypeInt,
-				Optional:     true,
-				Description:  "The uuid of the project. Either 'project_id' or 'project_name' must be specified.",
-				ExactlyOneOf: []string{"project_id", "project_name"},
+				Type:          schema.TypeInt,
+				Optional:      true,
+				Description:   "The ID of the project. Either 'project_id' or 'project_name' must be specified, unless the provider sets a default project.",
+				ConflictsWith: []string{"project_name"},
 			},
 			"project_name": {
-				Type:         schema.TypeString,
-				Optional:     true,
-				Description:  "The name of the project. Either 'project_id' or 'project_name' must be specified.",
-				ExactlyOneOf: []string{"project_id", "project_name"},
+				Type:          schema.TypeString,
+				Optional:      true,
+				Description:   "The name of the project. Either 'project_id' or 'project_name' must be specified, unless the provider sets a default project.",
+				ConflictsWith: []string{"project_id"},
 			},
 			"region_id": {
-				Type:         schema.TypeInt,
-				Optional:     true,
-				Description:  "The uuid of the region. Either 'region_id' or 'region_name' must be specified.",
-				ExactlyOneOf: []string{"region_id", "region_name"},
+				Type:          schema.TypeInt,
+				Optional:      true,
+				Description:   "The ID of the region. Either 'region_id' or 'region_name' must be specified, unless the provider sets a default region.",
+				ConflictsWith: []string{"region_name"},
 			},
 			"region_name": {
-				Type:         schema.TypeString,
-				Optional:     true,
-				Description:  "The name of the region. Either 'region_id' or 'region_name' must be specified.",
-				ExactlyOneOf: []string{"region_id", "region_name"},
+				Type:          schema.TypeString,
+				Optional:      true,
+				Description:   "The name of the region. Either 'region_id' or 'region_name' must be specified, unless the provider sets a default region.",
+				ConflictsWith: []string{"region_id"},
 			},
 			"name": {
 				Type:        schema.TypeString,
@@ -136,8 +136,8 @@ func dataSourceInstance() *schema.Resource {
 			"vm_state": {
 				Type:     schema.TypeString,
 				Computed: true,
-				Description: fmt.Sprintf(`The current virtual machine state of the instance, 
-allowing you to start or stop the VM. Possible values are %s and %s.`, InstanceVMStateStopped, InstanceVMStateActive),
+				Description: fmt.Sprintf(`The current virtual machine state of the instance, for example %s, %s, %s or %s.`,
+					InstanceVMStateStopped, InstanceVMStateActive, InstanceVMStateSuspended, InstanceVMStateRescued),
 			},
 			"addresses": {
 				Type:        schema.TypeList,
@@ -172,9 +172,8 @@ func dataSourceInstanceRead(_ context.Context, d *schema.ResourceData, m interfa
 	log.Println("[DEBUG] Start Instance reading")
 	var diags diag.Diagnostics
 	config := m.(*Config)
-	provider := config.Provider
 
-	client, err := CreateClient(provider, d, InstancePoint, VersionPointV1)
+	client, err := CreateClient(config, d, InstancePoint, VersionPointV1)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -274,20 +273,7 @@ func dataSourceInstanceRead(_ context.Context, d *schema.ResourceData, m interfa
 		return diag.FromErr(err)
 	}
 
-	addresses := []map[string][]map[string]string{}
-	for _, data := range instance.Addresses {
-		d := map[string][]map[string]string{}
-		netd := make([]map[string]string, len(data))
-		for i, iaddr := range data {
-			ndata := make(map[string]string, 2)
-			ndata["type"] = iaddr.Type.String()
-			ndata["addr"] = iaddr.Address.String()
-			netd[i] = ndata
-		}
-		d["net"] = netd
-		addresses = append(addresses, d)
-	}
-	if err := d.Set("addresses", addresses); err != nil {
+	if err := d.Set("addresses", instanceAddressesList(instance.Addresses)); err != nil {
 		return diag.FromErr(err)
 	}
 
@@ -0,0 +1,338 @@
+package edgecenter
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	edgecloud "github.com/Edge-Center/edgecentercloud-go"
+	"github.com/Edge-Center/edgecentercloud-go/edgecenter/instance/v1/instances"
+	"github.com/Edge-Center/edgecentercloud-go/edgecenter/servergroup/v1/servergroups"
+)
+
+// instanceListOpts extends instances.ListOpts with the filters the SDK doesn't send.
+type instanceListOpts struct {
+	FlavorID     string            `q:"flavor_id"`
+	Status       string            `q:"status"`
+	ChangesSince string            `q:"changes-since"`
+	MetadataK    string            `q:"metadata_k"`
+	MetadataKV   map[string]string `q:"metadata_kv"`
+}
+
+// ToInstanceListQuery formats an instanceListOpts into a query string.
+func (opts instanceListOpts) ToInstanceListQuery() (string, error) {
+	q, err := edgecloud.BuildQueryString(opts)
+	if err != nil {
+		return "", err
+	}
+	return q.String(), nil
+}
+
+func dataSourceInstances() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceInstancesRead,
+		Description: "Lists the instances of the project and region matching the filters, e.g. to build an inventory.",
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:          schema.TypeInt,
+				Optional:      true,
+				Description:   "The ID of the project. Either 'project_id' or 'project_name' must be specified, unless the provider sets a default project.",
+				ConflictsWith: []string{"project_name"},
+			},
+			"project_name": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Description:   "The name of the project. Either 'project_id' or 'project_name' must be specified, unless the provider sets a default project.",
+				ConflictsWith: []string{"project_id"},
+			},
+			"region_id": {
+				Type:          schema.TypeInt,
+				Optional:      true,
+				Description:   "The ID of the region. Either 'region_id' or 'region_name' must be specified, unless the provider sets a default region.",
+				ConflictsWith: []string{"region_name"},
+			},
+			"region_name": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Description:   "The name of the region. Either 'region_id' or 'region_name' must be specified, unless the provider sets a default region.",
+				ConflictsWith: []string{"region_id"},
+			},
+			"name_regex": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "A regular expression the names of the instances must match.",
+				ValidateFunc: validation.StringIsValidRegExp,
+			},
+			"metadata_k": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Filtration query opts (only key).",
+			},
+			"metadata_kv": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Description: `Filtration query opts, for example, {env = "prod"}`,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The status the instances must have, for example 'ACTIVE' or 'SHUTOFF'.",
+			},
+			"flavor_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The ID of the flavor the instances must have, for example 'g1-standard-2-4'.",
+			},
+			"servergroup_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The uuid of the server group the instances must belong to.",
+			},
+			"changes_since": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Only list the instances changed since the time, in RFC 3339 format, for example '2023-10-01T00:00:00Z'.",
+				ValidateFunc: validation.IsRFC3339Time,
+			},
+			"instances": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The instances matching the filters, ordered by name.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The uuid of the instance.",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the instance.",
+						},
+						"flavor_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the flavor of the instance.",
+						},
+						"status": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The current status of the instance.",
+						},
+						"vm_state": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The current virtual machine state of the instance.",
+						},
+						"metadata_map": {
+							Type:        schema.TypeMap,
+							Computed:    true,
+							Description: "The metadata of the instance.",
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"volume": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "The volumes attached to the instance.",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"volume_id": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"delete_on_termination": {
+										Type:     schema.TypeBool,
+										Computed: true,
+									},
+								},
+							},
+						},
+						"security_group": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "The firewalls applied to the instance, defined by their name.",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"name": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+								},
+							},
+						},
+						"addresses": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "The addresses of the instance grouped per network, ordered by the network name.",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"net": {
+										Type:     schema.TypeList,
+										Computed: true,
+										Elem: &schema.Resource{
+											Schema: map[string]*schema.Schema{
+												"addr": {
+													Type:     schema.TypeString,
+													Computed: true,
+												},
+												"type": {
+													Type:     schema.TypeString,
+													Computed: true,
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceInstancesRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Println("[DEBUG] Start Instances reading")
+	config := m.(*Config)
+
+	client, err := CreateClient(config, d, InstancePoint, VersionPointV1)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	opts := instanceListOpts{
+		FlavorID:  d.Get("flavor_id").(string),
+		Status:    d.Get("status").(string),
+		MetadataK: d.Get("metadata_k").(string),
+	}
+	if changesSince := d.Get("changes_since").(string); changesSince != "" {
+		// the value is validated by the schema
+		t, _ := time.Parse(time.RFC3339, changesSince)
+		opts.ChangesSince = t.UTC().Format(time.RFC3339)
+	}
+	if metadataRaw, ok := d.GetOk("metadata_kv"); ok {
+		typedMetadataKV := make(map[string]string, len(metadataRaw.(map[string]interface{})))
+		for k, v := range metadataRaw.(map[string]interface{}) {
+			typedMetadataKV[k] = v.(string)
+		}
+		opts.MetadataKV = typedMetadataKV
+	}
+
+	list, err := instances.ListAll(client, opts)
+	if err != nil {
+		return diag.Errorf("cannot list instances. Error: %s", err)
+	}
+
+	var nameRegex *regexp.Regexp
+	if v := d.Get("name_regex").(string); v != "" {
+		nameRegex = regexp.MustCompile(v)
+	}
+	var members map[string]bool
+	if serverGroupID := d.Get("servergroup_id").(string); serverGroupID != "" {
+		members, err = serverGroupMembers(config, d, serverGroupID)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	result := make([]map[string]interface{}, 0, len(list))
+	ids := make([]string, 0, len(list))
+	for _, instance := range list {
+		switch {
+		case nameRegex != nil && !nameRegex.MatchString(instance.Name),
+			members != nil && !members[instance.ID]:
+			continue
+		}
+		result = append(result, instanceToMap(instance))
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i]["name"].(string) < result[j]["name"].(string)
+	})
+	for _, instance := range result {
+		ids = append(ids, instance["id"].(string))
+	}
+
+	d.SetId(fmt.Sprint(schema.HashString(strings.Join(ids, ","))))
+	if err := d.Set("instances", result); err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Println("[DEBUG] Finish Instances reading")
+
+	return nil
+}
+
+// serverGroupMembers returns the IDs of the instances in the server group.
+func serverGroupMembers(config *Config, d *schema.ResourceData, serverGroupID string) (map[string]bool, error) {
+	client, err := CreateClient(config, d, ServerGroupsPoint, VersionPointV1)
+	if err != nil {
+		return nil, err
+	}
+
+	serverGroup, err := servergroups.Get(client, serverGroupID).Extract()
+	if err != nil {
+		return nil, fmt.Errorf("cannot get server group with ID: %s. Error: %w", serverGroupID, err)
+	}
+
+	members := make(map[string]bool, len(serverGroup.Instances))
+	for _, instance := range serverGroup.Instances {
+		members[instance.InstanceID] = true
+	}
+
+	return members, nil
+}
+
+// instanceToMap converts an instance into an element of the instances attribute.
+func instanceToMap(instance instances.Instance) map[string]interface{} {
+	metadata := make(map[string]interface{}, len(instance.Metadata))
+	for k, v := range instance.Metadata {
+		metadata[k] = fmt.Sprint(v)
+	}
+
+	volumes := make([]map[string]interface{}, 0, len(instance.Volumes))
+	for _, vol := range instance.Volumes {
+		volumes = append(volumes, map[string]interface{}{
+			"volume_id":             vol.ID,
+			"delete_on_termination": vol.DeleteOnTermination,
+		})
+	}
+
+	secGrps := make([]map[string]interface{}, 0, len(instance.SecurityGroups))
+	for _, sg := range instance.SecurityGroups {
+		secGrps = append(secGrps, map[string]interface{}{"name": sg.Name})
+	}
+
+	addresses := make([]interface{}, 0, len(instance.Addresses))
+	for _, network := range instanceAddressesList(instance.Addresses) {
+		netd := make([]interface{}, 0, len(network["net"]))
+		for _, addr := range network["net"] {
+			netd = append(netd, map[string]interface{}{"addr": addr["addr"], "type": addr["type"]})
+		}
+		addresses = append(addresses, map[string]interface{}{"net": netd})
+	}
+
+	return map[string]interface{}{
+		"id":             instance.ID,
+		"name":           instance.Name,
+		"flavor_id":      instance.Flavor.FlavorID,
+		"status":         instance.Status,
+		"vm_state":       instance.VMState,
+		"metadata_map":   metadata,
+		"volume":         volumes,
+		"security_group": secGrps,
+		"addresses":      addresses,
+	}
+}
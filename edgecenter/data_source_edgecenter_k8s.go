@@ -18,28 +18,28 @@ func dataSourceK8s() *schema.Resource {
 		Description: "Represent k8s cluster with one default pool.",
 		Schema: map[string]*schema.Schema{
 			"project_id": {
-				Type:         schema.TypeInt,
-				Optional:     true,
-				Description:  "The uuid of the project. Either 'project_id' or 'project_name' must be specified.",
-				ExactlyOneOf: []string{"project_id", "project_name"},
+				Type:          schema.TypeInt,
+				Optional:      true,
+				Description:   "The ID of the project. Either 'project_id' or 'project_name' must be specified, unless the provider sets a default project.",
+				ConflictsWith: []string{"project_name"},
 			},
 			"project_name": {
-				Type:         schema.TypeString,
-				Optional:     true,
-				Description:  "The name of the project. Either 'project_id' or 'project_name' must be specified.",
-				ExactlyOneOf: []string{"project_id", "project_name"},
+				Type:          schema.TypeString,
+				Optional:      true,
+				Description:   "The name of the project. Either 'project_id' or 'project_name' must be specified, unless the provider sets a default project.",
+				ConflictsWith: []string{"project_id"},
 			},
 			"region_id": {
-				Type:         schema.TypeInt,
-				Optional:     true,
-				Description:  "The uuid of the region. Either 'region_id' or 'region_name' must be specified.",
-				ExactlyOneOf: []string{"region_id", "region_name"},
+				Type:          schema.TypeInt,
+				Optional:      true,
+				Description:   "The ID of the region. Either 'region_id' or 'region_name' must be specified, unless the provider sets a default region.",
+				ConflictsWith: []string{"region_name"},
 			},
 			"region_name": {
-				Type:         schema.TypeString,
-				Optional:     true,
-				Description:  "The name of the region. Either 'region_id' or 'region_name' must be specified.",
-				ExactlyOneOf: []string{"region_id", "region_name"},
+				Type:          schema.TypeString,
+				Optional:      true,
+				Description:   "The name of the region. Either 'region_id' or 'region_name' must be specified, unless the provider sets a default region.",
+				ConflictsWith: []string{"region_id"},
 			},
 			"cluster_id": {
 				Type:        schema.TypeString,
@@ -226,9 +226,8 @@ func dataSourceK8sRead(_ context.Context, d *schema.ResourceData, m interface{})
 	log.Println("[DEBUG] Start K8s reading")
 	var diags diag.Diagnostics
 	config := m.(*Config)
-	provider := config.Provider
 
-	client, err := CreateClient(provider, d, K8sPoint, VersionPointV1)
+	client, err := CreateClient(config, d, K8sPoint, VersionPointV1)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -236,6 +235,9 @@ func dataSourceK8sRead(_ context.Context, d *schema.ResourceData, m interface{})
 	clusterID := d.Get("cluster_id").(string)
 	cluster, err := clusters.Get(client, clusterID).Extract()
 	if err != nil {
+		if isNotFound(err) {
+			return diag.Errorf("k8s cluster with ID %s not found", clusterID)
+		}
 		return diag.FromErr(err)
 	}
 
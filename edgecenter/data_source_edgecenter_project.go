@@ -27,8 +27,7 @@ func dataSourceProjectRead(_ context.Context, d *schema.ResourceData, m interfac
 	log.Println("[DEBUG] Start Project reading")
 	name := d.Get("name").(string)
 	config := m.(*Config)
-	provider := config.Provider
-	projectID, err := GetProject(provider, 0, name)
+	projectID, err := config.resolveProject(0, name)
 	if err != nil {
 		return diag.FromErr(err)
 	}
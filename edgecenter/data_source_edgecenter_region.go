@@ -28,8 +28,7 @@ func dataSourceRegionRead(_ context.Context, d *schema.ResourceData, m interface
 
 	name := d.Get("name").(string)
 	config := m.(*Config)
-	provider := config.Provider
-	regionID, err := GetRegion(provider, 0, name)
+	regionID, err := config.resolveRegion(0, name)
 	if err != nil {
 		return diag.FromErr(err)
 	}
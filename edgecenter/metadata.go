@@ -1,6 +1,12 @@
 package edgecenter
 
-import "github.com/Edge-Center/edgecentercloud-go/edgecenter/utils/metadata"
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/Edge-Center/edgecentercloud-go/edgecenter/utils/metadata"
+)
 
 func PrepareMetadata(apiMetadata []metadata.Metadata) (map[string]string, []map[string]interface{}) {
 	metadataMap := make(map[string]string)
@@ -37,3 +43,51 @@ func PrepareMetadataReadonly(apiMetadata []metadata.Metadata) []map[string]inter
 
 	return metadataReadOnly
 }
+
+// metadataWithDefaults returns metadataRaw, the metadata_map of a resource, merged over the provider default_metadata.
+// Values of the resource win on conflict.
+func metadataWithDefaults(config *Config, metadataRaw interface{}) map[string]interface{} {
+	metadataMap, _ := metadataRaw.(map[string]interface{})
+	merged := make(map[string]interface{}, len(config.DefaultMetadata)+len(metadataMap))
+	for k, v := range config.DefaultMetadata {
+		merged[k] = v
+	}
+	for k, v := range metadataMap {
+		merged[k] = v
+	}
+
+	return merged
+}
+
+// metadataWithoutDefaults removes from apiMetadata the keys which come from the provider default_metadata
+// and aren't managed by the resource itself, i.e. are absent from its metadata_map.
+func metadataWithoutDefaults(config *Config, apiMetadata map[string]string, metadataRaw interface{}) map[string]string {
+	managed, _ := metadataRaw.(map[string]interface{})
+	result := make(map[string]string, len(apiMetadata))
+	for k, v := range apiMetadata {
+		if _, isDefault := config.DefaultMetadata[k]; isDefault {
+			if _, ok := managed[k]; !ok {
+				continue
+			}
+		}
+		result[k] = v
+	}
+
+	return result
+}
+
+// customizeDiffMetadataAll plans metadata_all as the effective metadata of the resource,
+// so that the plan shows the provider default_metadata merged into metadata_map.
+func customizeDiffMetadataAll(_ context.Context, d *schema.ResourceDiff, m interface{}) error {
+	config := m.(*Config)
+
+	metadataMapConfigured := true
+	if rawConfig := d.GetRawConfig(); !rawConfig.IsNull() {
+		metadataMapConfigured = !rawConfig.GetAttr("metadata_map").IsNull()
+	}
+	if !d.NewValueKnown("metadata_map") && metadataMapConfigured {
+		return d.SetNewComputed("metadata_all") //nolint: wrapcheck
+	}
+
+	return d.SetNew("metadata_all", metadataWithDefaults(config, d.Get("metadata_map"))) //nolint: wrapcheck
+}
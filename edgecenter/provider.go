@@ -6,23 +6,31 @@ import (
 	"log"
 	"net/http"
 	"net/url"
-	"os"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
 	dnssdk "github.com/Edge-Center/edgecenter-dns-sdk-go"
 	storageSDK "github.com/Edge-Center/edgecenter-storage-sdk-go"
 	cdn "github.com/Edge-Center/edgecentercdn-go"
-	eccdnProvider "github.com/Edge-Center/edgecentercdn-go/edgecenter/provider"
 	edgecloud "github.com/Edge-Center/edgecentercloud-go"
 	ec "github.com/Edge-Center/edgecentercloud-go/edgecenter"
+	"github.com/Edge-Center/edgecentercloud-go/edgecenter/utils"
 )
 
 const (
 	ProviderOptPermanentToken    = "permanent_api_token"
 	ProviderOptSkipCredsAuthErr  = "ignore_creds_auth_error" // nolint: gosec
 	ProviderOptSingleAPIEndpoint = "api_endpoint"
+	ProviderOptMaxRetries        = "max_retries"
+	ProviderOptRetryMaxWait      = "retry_max_wait"
+	ProviderOptMaxConcurrentReqs = "max_concurrent_requests"
+	ProviderOptProfile           = "profile"
+	ProviderOptCredentialsFile   = "credentials_file"
+
+	defaultAPIEndpoint = "https://api.edgecenter.ru"
 
 	LifecyclePolicyResource = "edgecenter_lifecyclepolicy"
 )
@@ -60,7 +68,19 @@ func Provider() *schema.Provider {
 				Type:        schema.TypeString,
 				Optional:    true,
 				Description: "A single API endpoint for all products. Will be used when specific product API url is not defined.",
-				DefaultFunc: schema.EnvDefaultFunc("EC_API_ENDPOINT", "https://api.edgecenter.ru"),
+				DefaultFunc: schema.EnvDefaultFunc("EC_API_ENDPOINT", nil),
+			},
+			ProviderOptProfile: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The profile of the shared credentials file to take the permanent token and API endpoints from. Provider arguments, the deprecated `edgecenter_api` and `edgecenter_platform` included, and their environment variables take precedence over the profile. Defaults to the `default` profile, if the file defines it.",
+				DefaultFunc: schema.EnvDefaultFunc("EC_PROFILE", ""),
+			},
+			ProviderOptCredentialsFile: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The path of the shared credentials file, a YAML map of profile names to provider arguments. Defaults to `~/.edgecenter/credentials`.",
+				DefaultFunc: schema.EnvDefaultFunc("EC_CREDENTIALS_FILE", defaultCredentialsFile),
 			},
 			ProviderOptSkipCredsAuthErr: {
 				Type:        schema.TypeBool,
@@ -120,37 +140,121 @@ func Provider() *schema.Provider {
 				Description: "Client id",
 				DefaultFunc: schema.EnvDefaultFunc("EC_CLIENT_ID", ""),
 			},
+			ProviderOptMaxRetries: {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "How many times a request throttled or failed by a temporarily unavailable API is retried. Set to 0 to disable retries.",
+				DefaultFunc: schema.EnvDefaultFunc("EC_MAX_RETRIES", 3),
+			},
+			ProviderOptRetryMaxWait: {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "The maximum delay between retries in seconds, also applied to the Retry-After header of the API.",
+				DefaultFunc: schema.EnvDefaultFunc("EC_RETRY_MAX_WAIT", 30),
+			},
+			ProviderOptMaxConcurrentReqs: {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Limits how many requests the provider sends to each API at the same time. Requests over the limit wait for a free slot.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"cloud": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							ValidateFunc: validation.IntAtLeast(0),
+							Description:  "The limit for the cloud API, task polling included. 0 means no limit.",
+						},
+						"cdn": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							ValidateFunc: validation.IntAtLeast(0),
+							Description:  "The limit for the CDN API. 0 means no limit.",
+						},
+						"dns": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							ValidateFunc: validation.IntAtLeast(0),
+							Description:  "The limit for the DNS API. 0 means no limit.",
+						},
+						"storage": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							ValidateFunc: validation.IntAtLeast(0),
+							Description:  "The limit for the storage API. 0 means no limit.",
+						},
+					},
+				},
+			},
+			"project_id": {
+				Type:          schema.TypeInt,
+				Optional:      true,
+				ConflictsWith: []string{"project_name"},
+				Description:   "The ID of the default project for cloud resources and data sources which don't specify their own project.",
+				DefaultFunc:   schema.EnvDefaultFunc("EC_PROJECT_ID", nil),
+			},
+			"project_name": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"project_id"},
+				Description:   "The name of the default project for cloud resources and data sources which don't specify their own project.",
+			},
+			"region_id": {
+				Type:          schema.TypeInt,
+				Optional:      true,
+				ConflictsWith: []string{"region_name"},
+				Description:   "The ID of the default region for cloud resources and data sources which don't specify their own region.",
+				DefaultFunc:   schema.EnvDefaultFunc("EC_REGION_ID", nil),
+			},
+			"region_name": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"region_id"},
+				Description:   "The name of the default region for cloud resources and data sources which don't specify their own region.",
+			},
+			"default_metadata": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Description: "Metadata added to every cloud resource with a `metadata_map`. Keys set in the `metadata_map` of a resource take precedence.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
 		},
 		ResourcesMap: map[string]*schema.Resource{
-			"edgecenter_volume":            resourceVolume(),
-			"edgecenter_network":           resourceNetwork(),
-			"edgecenter_subnet":            resourceSubnet(),
-			"edgecenter_router":            resourceRouter(),
-			"edgecenter_instance":          resourceInstance(),
-			"edgecenter_keypair":           resourceKeypair(),
-			"edgecenter_reservedfixedip":   resourceReservedFixedIP(),
-			"edgecenter_floatingip":        resourceFloatingIP(),
-			"edgecenter_loadbalancer":      resourceLoadBalancer(),
-			"edgecenter_loadbalancerv2":    resourceLoadBalancerV2(),
-			"edgecenter_lblistener":        resourceLbListener(),
-			"edgecenter_lbpool":            resourceLBPool(),
-			"edgecenter_lbmember":          resourceLBMember(),
-			"edgecenter_securitygroup":     resourceSecurityGroup(),
-			"edgecenter_baremetal":         resourceBmInstance(),
-			"edgecenter_snapshot":          resourceSnapshot(),
-			"edgecenter_servergroup":       resourceServerGroup(),
-			"edgecenter_k8s":               resourceK8s(),
-			"edgecenter_k8s_pool":          resourceK8sPool(),
-			"edgecenter_secret":            resourceSecret(),
-			"edgecenter_storage_s3":        resourceStorageS3(),
-			"edgecenter_storage_s3_bucket": resourceStorageS3Bucket(),
-			DNSZoneResource:                resourceDNSZone(),
-			DNSZoneRecordResource:          resourceDNSZoneRecord(),
-			"edgecenter_cdn_resource":      resourceCDNResource(),
-			"edgecenter_cdn_origingroup":   resourceCDNOriginGroup(),
-			"edgecenter_cdn_rule":          resourceCDNRule(),
-			"edgecenter_cdn_sslcert":       resourceCDNCert(),
-			LifecyclePolicyResource:        resourceLifecyclePolicy(),
+			"edgecenter_volume":             resourceVolume(),
+			"edgecenter_volume_attachment":  resourceVolumeAttachment(),
+			"edgecenter_network":            resourceNetwork(),
+			"edgecenter_subnet":             resourceSubnet(),
+			"edgecenter_router":             resourceRouter(),
+			"edgecenter_instance":           resourceInstance(),
+			"edgecenter_instance_interface": resourceInstanceInterface(),
+			"edgecenter_keypair":            resourceKeypair(),
+			"edgecenter_reservedfixedip":    resourceReservedFixedIP(),
+			"edgecenter_floatingip":         resourceFloatingIP(),
+			"edgecenter_loadbalancer":       resourceLoadBalancer(),
+			"edgecenter_loadbalancerv2":     resourceLoadBalancerV2(),
+			"edgecenter_lblistener":         resourceLbListener(),
+			"edgecenter_lbpool":             resourceLBPool(),
+			"edgecenter_lbmember":           resourceLBMember(),
+			"edgecenter_securitygroup":      resourceSecurityGroup(),
+			"edgecenter_baremetal":          resourceBmInstance(),
+			"edgecenter_snapshot":           resourceSnapshot(),
+			"edgecenter_image":              resourceImage(),
+			"edgecenter_servergroup":        resourceServerGroup(),
+			"edgecenter_servergroup_member": resourceServerGroupMember(),
+			"edgecenter_k8s":                resourceK8s(),
+			"edgecenter_k8s_pool":           resourceK8sPool(),
+			"edgecenter_secret":             resourceSecret(),
+			"edgecenter_storage_s3":         resourceStorageS3(),
+			"edgecenter_storage_s3_bucket":  resourceStorageS3Bucket(),
+			DNSZoneResource:                 resourceDNSZone(),
+			DNSZoneRecordResource:           resourceDNSZoneRecord(),
+			"edgecenter_cdn_resource":       resourceCDNResource(),
+			"edgecenter_cdn_origingroup":    resourceCDNOriginGroup(),
+			"edgecenter_cdn_rule":           resourceCDNRule(),
+			"edgecenter_cdn_sslcert":        resourceCDNCert(),
+			LifecyclePolicyResource:         resourceLifecyclePolicy(),
 		},
 		DataSourcesMap: map[string]*schema.Resource{
 			"edgecenter_project":           dataSourceProject(),
@@ -175,6 +279,10 @@ func Provider() *schema.Provider {
 			"edgecenter_k8s_pool":          dataSourceK8sPool(),
 			"edgecenter_k8s_client_config": dataSourceK8sClientConfig(),
 			"edgecenter_secret":            dataSourceSecret(),
+			"edgecenter_flavor":            dataSourceFlavor(),
+			"edgecenter_flavors":           dataSourceFlavors(),
+			"edgecenter_cloudinit_config":  dataSourceCloudInitConfig(),
+			"edgecenter_instances":         dataSourceInstances(),
 		},
 	}
 
@@ -189,48 +297,65 @@ func Provider() *schema.Provider {
 	return p
 }
 
-func providerConfigure(_ context.Context, d *schema.ResourceData, terraformVersion string) (interface{}, diag.Diagnostics) {
+func providerConfigure(ctx context.Context, d *schema.ResourceData, terraformVersion string) (interface{}, diag.Diagnostics) {
+	profile, err := loadCredentialsProfile(d.Get(ProviderOptCredentialsFile).(string), d.Get(ProviderOptProfile).(string))
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+
 	username := d.Get("user_name").(string)
 	password := d.Get("password").(string)
-	permanentToken := d.Get(ProviderOptPermanentToken).(string)
-	apiEndpoint := d.Get(ProviderOptSingleAPIEndpoint).(string)
-
-	cloudAPI := d.Get("edgecenter_cloud_api").(string)
-	if cloudAPI == "" {
-		cloudAPI = d.Get("edgecenter_api").(string)
+	permanentToken := profile.getString(d, ProviderOptPermanentToken)
+	apiEndpoint := profile.getString(d, ProviderOptSingleAPIEndpoint)
+	if apiEndpoint == "" {
+		apiEndpoint = defaultAPIEndpoint
 	}
+
+	cloudAPI := profile.getString(d, "edgecenter_cloud_api", "edgecenter_api")
 	if cloudAPI == "" {
 		cloudAPI = apiEndpoint + "/cloud"
 	}
 
-	cdnAPI := d.Get("edgecenter_cdn_api").(string)
+	cdnAPI := profile.getString(d, "edgecenter_cdn_api")
 	if cdnAPI == "" {
 		cdnAPI = apiEndpoint
 	}
 
-	storageAPI := d.Get("edgecenter_storage_api").(string)
+	storageAPI := profile.getString(d, "edgecenter_storage_api")
 	if storageAPI == "" {
 		storageAPI = apiEndpoint + "/storage"
 	}
 
-	dnsAPI := d.Get("edgecenter_dns_api").(string)
+	dnsAPI := profile.getString(d, "edgecenter_dns_api")
 	if dnsAPI == "" {
 		dnsAPI = apiEndpoint + "/dns"
 	}
 
-	platform := d.Get("edgecenter_platform_api").(string)
-	if platform == "" {
-		platform = d.Get("edgecenter_platform").(string)
-	}
+	platform := profile.getString(d, "edgecenter_platform_api", "edgecenter_platform")
 	if platform == "" {
 		platform = apiEndpoint + "/iam"
 	}
 
 	clientID := d.Get("edgecenter_client_id").(string)
 
+	defaultMetadata, err := utils.MapInterfaceToMapString(d.Get("default_metadata"))
+	if err != nil {
+		return nil, diag.Errorf("default_metadata: %s", err)
+	}
+
+	maxRetries := d.Get(ProviderOptMaxRetries).(int)
+	retryMaxWait := time.Duration(d.Get(ProviderOptRetryMaxWait).(int)) * time.Second
+	// clientTransport stacks retries, token refresh, the concurrency limit of the api and request tracing over next.
+	clientTransport := func(api string, next http.RoundTripper, tokens *tokenSource) http.RoundTripper {
+		next = newLoggingTransport(next, api, permanentToken, password)
+		next = newConcurrencyLimitTransport(next, d.Get(fmt.Sprintf("%s.0.%s", ProviderOptMaxConcurrentReqs, api)).(int))
+		next = newTokenAuthTransport(next, tokens)
+
+		return newRetryTransport(next, maxRetries, retryMaxWait)
+	}
+
 	var diags diag.Diagnostics
 
-	var err error
 	var provider *edgecloud.ProviderClient
 	if permanentToken != "" {
 		provider, err = ec.APITokenClient(edgecloud.APITokenOptions{
@@ -251,22 +376,43 @@ func providerConfigure(_ context.Context, d *schema.ResourceData, terraformVersi
 		provider = &edgecloud.ProviderClient{}
 		log.Printf("[WARN] init auth client: %s\n", err)
 	}
+	// the cloud SDK takes no context per call, its requests carry this one and so the provider logger
+	provider.Context = detachedContext{ctx}
+	// the cloud client refreshes its own token on 401, and its re-authentication requests mustn't carry the stale one
+	provider.HTTPClient.Transport = clientTransport("cloud", provider.HTTPClient.Transport, nil)
+	// tokens authorizes the other product clients in the user_name/password flow, it stays nil with a permanent token
+	var tokens *tokenSource
+	if permanentToken == "" {
+		tokens = &tokenSource{provider: provider}
+	}
+
+	userAgent := fmt.Sprintf("terraform/%s", terraformVersion)
 
-	cdnProvider := eccdnProvider.NewClient(cdnAPI, eccdnProvider.WithSignerFunc(func(req *http.Request) error {
+	cdnProvider := newCDNClient(cdnAPI, userAgent, clientTransport("cdn", nil, tokens), func(req *http.Request) error {
 		for k, v := range provider.AuthenticatedHeaders() {
 			req.Header.Set(k, v)
 		}
 
 		return nil
-	}))
+	})
 	cdnService := cdn.NewService(cdnProvider)
 
+	storageTransport := roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		req.Header.Set("User-Agent", userAgent)
+		return http.DefaultTransport.RoundTrip(req)
+	})
+
 	config := Config{
-		Provider:  provider,
-		CDNClient: cdnService,
+		Provider:          provider,
+		CDNClient:         cdnService,
+		StorageHTTPClient: &http.Client{Transport: clientTransport("storage", storageTransport, tokens)},
+		ProjectID:         d.Get("project_id").(int),
+		ProjectName:       d.Get("project_name").(string),
+		RegionID:          d.Get("region_id").(int),
+		RegionName:        d.Get("region_name").(string),
+		DefaultMetadata:   defaultMetadata,
 	}
 
-	userAgent := fmt.Sprintf("terraform/%s", terraformVersion)
 	if storageAPI != "" {
 		stHost, stPath, err := ExtractHostAndPath(storageAPI)
 		if err != nil {
@@ -285,6 +431,7 @@ func providerConfigure(_ context.Context, d *schema.ResourceData, terraformVersi
 		if err != nil {
 			return nil, diag.FromErr(fmt.Errorf("dns api url: %w", err))
 		}
+		// the captured token is only a placeholder, tokenAuthTransport sets the current one on every request
 		authorizer := dnssdk.BearerAuth(provider.AccessToken())
 		if permanentToken != "" {
 			authorizer = dnssdk.PermanentAPIKeyAuth(permanentToken)
@@ -293,10 +440,10 @@ func providerConfigure(_ context.Context, d *schema.ResourceData, terraformVersi
 			authorizer,
 			func(client *dnssdk.Client) {
 				client.BaseURL = baseURL
-				client.Debug = os.Getenv("TF_LOG") == "DEBUG"
 			},
 			func(client *dnssdk.Client) {
 				client.UserAgent = userAgent
+				client.HTTPClient.Transport = clientTransport("dns", client.HTTPClient.Transport, tokens)
 			})
 	}
 
@@ -2,7 +2,6 @@ package edgecenter
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"log"
 	"sort"
@@ -12,7 +11,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
-	edgecloud "github.com/Edge-Center/edgecentercloud-go"
 	"github.com/Edge-Center/edgecentercloud-go/edgecenter/baremetal/v1/bminstances"
 	"github.com/Edge-Center/edgecentercloud-go/edgecenter/instance/v1/instances"
 	"github.com/Edge-Center/edgecentercloud-go/edgecenter/instance/v1/types"
@@ -33,13 +31,16 @@ func resourceBmInstance() *schema.Resource {
 		ReadContext:   resourceBmInstanceRead,
 		UpdateContext: resourceBmInstanceUpdate,
 		DeleteContext: resourceBmInstanceDelete,
+		CustomizeDiff: resourceBmInstanceCustomizeDiff,
 		Description:   "Represent baremetal instance",
 		Timeouts: &schema.ResourceTimeout{
 			Create: &bmCreateTimeout,
+			Update: &bmCreateTimeout,
+			Delete: schema.DefaultTimeout(time.Duration(BmInstanceDeleting) * time.Second),
 		},
 		Importer: &schema.ResourceImporter{
 			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-				projectID, regionID, InstanceID, err := ImportStringParser(d.Id())
+				projectID, regionID, InstanceID, err := ImportStringParser(meta.(*Config), d.Id())
 				if err != nil {
 					return nil, err
 				}
@@ -53,28 +54,30 @@ func resourceBmInstance() *schema.Resource {
 
 		Schema: map[string]*schema.Schema{
 			"project_id": {
-				Type:         schema.TypeInt,
-				Optional:     true,
-				Description:  "The uuid of the project. Either 'project_id' or 'project_name' must be specified.",
-				ExactlyOneOf: []string{"project_id", "project_name"},
+				Type:          schema.TypeInt,
+				Optional:      true,
+				Computed:      true,
+				Description:   "The ID of the project. Either 'project_id' or 'project_name' must be specified, unless the provider sets a default project.",
+				ConflictsWith: []string{"project_name"},
 			},
 			"project_name": {
-				Type:         schema.TypeString,
-				Optional:     true,
-				Description:  "The name of the project. Either 'project_id' or 'project_name' must be specified.",
-				ExactlyOneOf: []string{"project_id", "project_name"},
+				Type:          schema.TypeString,
+				Optional:      true,
+				Description:   "The name of the project. Either 'project_id' or 'project_name' must be specified, unless the provider sets a default project.",
+				ConflictsWith: []string{"project_id"},
 			},
 			"region_id": {
-				Type:         schema.TypeInt,
-				Optional:     true,
-				Description:  "The uuid of the region. Either 'region_id' or 'region_name' must be specified.",
-				ExactlyOneOf: []string{"region_id", "region_name"},
+				Type:          schema.TypeInt,
+				Optional:      true,
+				Computed:      true,
+				Description:   "The ID of the region. Either 'region_id' or 'region_name' must be specified, unless the provider sets a default region.",
+				ConflictsWith: []string{"region_name"},
 			},
 			"region_name": {
-				Type:         schema.TypeString,
-				Optional:     true,
-				Description:  "The name of the region. Either 'region_id' or 'region_name' must be specified.",
-				ExactlyOneOf: []string{"region_id", "region_name"},
+				Type:          schema.TypeString,
+				Optional:      true,
+				Description:   "The name of the region. Either 'region_id' or 'region_name' must be specified, unless the provider sets a default region.",
+				ConflictsWith: []string{"region_id"},
 			},
 			"flavor_id": {
 				Type:     schema.TypeString,
@@ -157,14 +160,18 @@ func resourceBmInstance() *schema.Resource {
 			"image_id": {
 				Type:     schema.TypeString,
 				Optional: true,
+				Description: `The ID of the image to install. Changing it rebuilds the server in place, keeping its ID,
+interfaces and floating IPs. The rebuild doesn't apply 'user_data', 'keypair_name' and 'app_config',
+the server keeps those it was created with.`,
 				ExactlyOneOf: []string{
 					"image_id",
 					"apptemplate_id",
 				},
 			},
 			"apptemplate_id": {
-				Type:     schema.TypeString,
-				Optional: true,
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The ID of the application template to install. It can't be changed, the rebuild of the server takes an image only.",
 				ExactlyOneOf: []string{
 					"image_id",
 					"apptemplate_id",
@@ -214,8 +221,11 @@ func resourceBmInstance() *schema.Resource {
 				Optional: true,
 			},
 			"user_data": {
-				Type:     schema.TypeString,
-				Optional: true,
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validateEncodedUserData,
+				Description: fmt.Sprintf(`The base64-encoded user data to configure the server with, e.g. rendered by edgecenter_cloudinit_config
+with base64_encode set. It is sent as is and may not exceed %d bytes.`, UserDataMaxSize),
 			},
 
 			// computed
@@ -262,6 +272,14 @@ func resourceBmInstance() *schema.Resource {
 				Description: "The timestamp of the last update (use with update context).",
 			},
 		},
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{
+			{
+				Version: 0,
+				Type:    resourceBmInstanceV0().CoreConfigSchema().ImpliedType(),
+				Upgrade: upgradeComputeStateV0,
+			},
+		},
 	}
 }
 
@@ -269,9 +287,8 @@ func resourceBmInstanceCreate(ctx context.Context, d *schema.ResourceData, m int
 	log.Println("[DEBUG] Start BaremetalInstance creating")
 	var diags diag.Diagnostics
 	config := m.(*Config)
-	provider := config.Provider
 
-	client, err := CreateClient(provider, d, BmInstancePoint, VersionPointV1)
+	client, err := CreateClient(config, d, BmInstancePoint, VersionPointV1)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -351,7 +368,7 @@ func resourceBmInstanceCreate(ctx context.Context, d *schema.ResourceData, m int
 
 	taskID := results.Tasks[0]
 
-	InstanceID, err := tasks.WaitTaskAndReturnResult(client, taskID, true, BmInstanceCreatingTimeout, func(task tasks.TaskID) (interface{}, error) {
+	InstanceID, err := waitTaskAndReturnResult(ctx, client, taskID, d.Timeout(schema.TimeoutCreate), func(task tasks.TaskID) (interface{}, error) {
 		taskInfo, err := tasks.Get(client, string(task)).Extract()
 		if err != nil {
 			return nil, fmt.Errorf("cannot get task with ID: %s. Error: %w", task, err)
@@ -380,17 +397,19 @@ func resourceBmInstanceRead(_ context.Context, d *schema.ResourceData, m interfa
 	log.Println("[DEBUG] Start Baremetal Instance reading")
 	var diags diag.Diagnostics
 	config := m.(*Config)
-	provider := config.Provider
 	instanceID := d.Id()
 	log.Printf("[DEBUG] Instance id = %s", instanceID)
 
-	client, err := CreateClient(provider, d, InstancePoint, VersionPointV1)
+	client, err := CreateClient(config, d, InstancePoint, VersionPointV1)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
 	instance, err := instances.Get(client, instanceID).Extract()
 	if err != nil {
+		if removeIfNotFound(d, "baremetal instance", err) {
+			return nil
+		}
 		return diag.Errorf("cannot get instance with ID: %s. Error: %s", instanceID, err)
 	}
 
@@ -527,26 +546,10 @@ func resourceBmInstanceRead(_ context.Context, d *schema.ResourceData, m interfa
 		}
 	}
 
-	addresses := []map[string][]map[string]string{}
-	for _, data := range instance.Addresses {
-		d := map[string][]map[string]string{}
-		netd := make([]map[string]string, len(data))
-		for i, iaddr := range data {
-			ndata := make(map[string]string, 2)
-			ndata["type"] = iaddr.Type.String()
-			ndata["addr"] = iaddr.Address.String()
-			netd[i] = ndata
-		}
-		d["net"] = netd
-		addresses = append(addresses, d)
-	}
-	if err := d.Set("addresses", addresses); err != nil {
+	if err := d.Set("addresses", instanceAddressesList(instance.Addresses)); err != nil {
 		return diag.FromErr(err)
 	}
 
-	fields := []string{"user_data", "app_config"}
-	revertState(d, &fields)
-
 	log.Println("[DEBUG] Finish Instance reading")
 
 	return diags
@@ -557,8 +560,7 @@ func resourceBmInstanceUpdate(ctx context.Context, d *schema.ResourceData, m int
 	instanceID := d.Id()
 	log.Printf("[DEBUG] Instance id = %s", instanceID)
 	config := m.(*Config)
-	provider := config.Provider
-	client, err := CreateClient(provider, d, InstancePoint, VersionPointV1)
+	client, err := CreateClient(config, d, InstancePoint, VersionPointV1)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -634,6 +636,12 @@ func resourceBmInstanceUpdate(ctx context.Context, d *schema.ResourceData, m int
 		}
 	}
 
+	if d.HasChange("image_id") && d.Get("image_id").(string) != "" {
+		if err := rebuildBmInstance(ctx, config, d); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	if d.HasChange("interface") {
 		ifsOldRaw, ifsNewRaw := d.GetChange("interface")
 
@@ -661,7 +669,7 @@ func resourceBmInstanceUpdate(ctx context.Context, d *schema.ResourceData, m int
 				return diag.FromErr(err)
 			}
 			taskID := results.Tasks[0]
-			_, err = tasks.WaitTaskAndReturnResult(client, taskID, true, InstanceCreatingTimeout, func(task tasks.TaskID) (interface{}, error) {
+			_, err = waitTaskAndReturnResult(ctx, client, taskID, d.Timeout(schema.TimeoutUpdate), func(task tasks.TaskID) (interface{}, error) {
 				taskInfo, err := tasks.Get(client, string(task)).Extract()
 				if err != nil {
 					return nil, fmt.Errorf("cannot get task with ID: %s. Error: %w, task: %+v", task, err, taskInfo)
@@ -708,7 +716,7 @@ func resourceBmInstanceUpdate(ctx context.Context, d *schema.ResourceData, m int
 				return diag.Errorf("cannot attach interface: %s. Error: %s", iType, err)
 			}
 			taskID := results.Tasks[0]
-			_, err = tasks.WaitTaskAndReturnResult(client, taskID, true, InstanceCreatingTimeout, func(task tasks.TaskID) (interface{}, error) {
+			_, err = waitTaskAndReturnResult(ctx, client, taskID, d.Timeout(schema.TimeoutUpdate), func(task tasks.TaskID) (interface{}, error) {
 				taskInfo, err := tasks.Get(client, string(task)).Extract()
 				if err != nil {
 					return nil, fmt.Errorf("cannot get task with ID: %s. Error: %w, task: %+v", task, err, taskInfo)
@@ -723,20 +731,58 @@ func resourceBmInstanceUpdate(ctx context.Context, d *schema.ResourceData, m int
 	}
 
 	d.Set("last_updated", time.Now().Format(time.RFC850))
+
+	// neither an update nor the rebuild applies user_data and app_config, the server keeps those it was created with
+	fields := []string{"user_data", "app_config"}
+	revertState(d, &fields)
+
 	log.Println("[DEBUG] Finish Instance updating")
 
 	return resourceBmInstanceRead(ctx, d, m)
 }
 
-func resourceBmInstanceDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+// rebuildBmInstance reinstalls the server from the current image.
+// The server keeps its ID, interfaces and floating IPs. The rebuild options of the API carry the image only,
+// so the key pair, user data and application config stay those the server was created with.
+func rebuildBmInstance(ctx context.Context, config *Config, d *schema.ResourceData) error {
+	client, err := CreateClient(config, d, BmInstancePoint, VersionPointV1)
+	if err != nil {
+		return err
+	}
+
+	opts := bminstances.RebuildInstanceOpts{ImageID: d.Get("image_id").(string)}
+
+	log.Printf("[DEBUG] Rebuild baremetal instance %s with image %q", d.Id(), opts.ImageID)
+	results, err := bminstances.Rebuild(client, d.Id(), opts).Extract()
+	if err != nil {
+		return fmt.Errorf("cannot rebuild baremetal instance with ID: %s. Error: %w", d.Id(), err)
+	}
+	taskID := results.Tasks[0]
+	log.Printf("[DEBUG] Task id (%s)", taskID)
+	if err := waitTask(ctx, client, taskID, d.Timeout(schema.TimeoutUpdate)); err != nil {
+		return fmt.Errorf("cannot rebuild baremetal instance with ID: %s. Error: %w", d.Id(), err)
+	}
+
+	return nil
+}
+
+// resourceBmInstanceCustomizeDiff rejects a change of apptemplate_id, as the rebuild of a server takes an image only.
+func resourceBmInstanceCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if d.Id() != "" && d.HasChange("apptemplate_id") && d.Get("apptemplate_id").(string) != "" {
+		return fmt.Errorf("cannot change apptemplate_id of baremetal instance %s, it can only be rebuilt from an image_id", d.Id())
+	}
+
+	return nil
+}
+
+func resourceBmInstanceDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	log.Println("[DEBUG] Start Baremetal Instance deleting")
 	var diags diag.Diagnostics
 	config := m.(*Config)
-	provider := config.Provider
 	instanceID := d.Id()
 	log.Printf("[DEBUG] Instance id = %s", instanceID)
 
-	client, err := CreateClient(provider, d, InstancePoint, VersionPointV1)
+	client, err := CreateClient(config, d, InstancePoint, VersionPointV1)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -750,13 +796,12 @@ func resourceBmInstanceDelete(_ context.Context, d *schema.ResourceData, m inter
 	}
 	taskID := results.Tasks[0]
 	log.Printf("[DEBUG] Task id (%s)", taskID)
-	_, err = tasks.WaitTaskAndReturnResult(client, taskID, true, BmInstanceDeleting, func(task tasks.TaskID) (interface{}, error) {
+	_, err = waitTaskAndReturnResult(ctx, client, taskID, d.Timeout(schema.TimeoutDelete), func(task tasks.TaskID) (interface{}, error) {
 		_, err := instances.Get(client, instanceID).Extract()
 		if err == nil {
 			return nil, fmt.Errorf("cannot delete instance with ID: %s", instanceID)
 		}
-		var errDefault404 edgecloud.Default404Error
-		if errors.As(err, &errDefault404) {
+		if isNotFound(err) {
 			return nil, nil
 		}
 		return nil, fmt.Errorf("extracting Instance resource error: %w", err)
@@ -0,0 +1,188 @@
+package edgecenter
+
+import "github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+// resourceBmInstanceV0 is the schema of edgecenter_baremetal state version 0, frozen for its state upgrader.
+// Only the types matter to decode old state, so descriptions, defaults and validation are left out.
+func resourceBmInstanceV0() *schema.Resource {
+	return &schema.Resource{
+		Timeouts: &schema.ResourceTimeout{
+			Create: &bmCreateTimeout,
+		},
+		Schema: map[string]*schema.Schema{
+			"addresses": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"net": {
+							Type:     schema.TypeList,
+							Required: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"addr": {
+										Type:     schema.TypeString,
+										Required: true,
+									},
+									"type": {
+										Type:     schema.TypeString,
+										Required: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"app_config": {
+				Type:     schema.TypeMap,
+				Optional: true,
+			},
+			"apptemplate_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"flavor": {
+				Type:     schema.TypeMap,
+				Computed: true,
+			},
+			"flavor_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"image_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"interface": {
+				Type:     schema.TypeList,
+				Required: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"existing_fip_id": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"fip_source": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"ip_address": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Computed: true,
+						},
+						"is_parent": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Computed: true,
+						},
+						"network_id": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Computed: true,
+						},
+						"order": {
+							Type:     schema.TypeInt,
+							Optional: true,
+						},
+						"port_id": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Computed: true,
+						},
+						"subnet_id": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Computed: true,
+						},
+						"type": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+					},
+				},
+			},
+			"keypair_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"last_updated": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"metadata": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"key": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"value": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+					},
+				},
+			},
+			"metadata_map": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"name_template": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"name_templates": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"password": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"project_id": {
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+			"project_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"region_id": {
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+			"region_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"user_data": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"username": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"vm_state": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
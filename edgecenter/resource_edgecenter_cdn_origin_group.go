@@ -106,6 +106,9 @@ func resourceCDNOriginGroupRead(ctx context.Context, d *schema.ResourceData, m i
 
 	result, err := client.OriginGroups().Get(ctx, id)
 	if err != nil {
+		if removeIfNotFound(d, "CDN origin group", err) {
+			return nil
+		}
 		return diag.FromErr(err)
 	}
 
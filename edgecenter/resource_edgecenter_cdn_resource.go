@@ -1033,6 +1033,14 @@ func resourceCDNResource() *schema.Resource {
 		UpdateContext: resourceCDNResourceUpdate,
 		DeleteContext: resourceCDNResourceDelete,
 		Description:   "Represent CDN resource",
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{
+			{
+				Version: 0,
+				Type:    resourceCDNResourceV0().CoreConfigSchema().ImpliedType(),
+				Upgrade: upgradeCDNOptionsStateV0,
+			},
+		},
 	}
 }
 
@@ -1087,6 +1095,9 @@ func resourceCDNResourceRead(ctx context.Context, d *schema.ResourceData, m inte
 
 	result, err := client.Resources().Get(ctx, id)
 	if err != nil {
+		if removeIfNotFound(d, "CDN resource", err) {
+			return nil
+		}
 		return diag.FromErr(err)
 	}
 
@@ -1101,7 +1112,9 @@ func resourceCDNResourceRead(ctx context.Context, d *schema.ResourceData, m inte
 	d.Set("status", result.Status)
 	d.Set("active", result.Active)
 	d.Set("ssl_le_enabled", result.SSLLEEnabled)
-	if err := d.Set("options", resourceOptionsToList(result.Options)); err != nil {
+	options := resourceOptionsToList(result.Options)
+	keepUpgradedCDNOptions(d.Get("options").([]interface{}), options)
+	if err := d.Set("options", options); err != nil {
 		return diag.FromErr(err)
 	}
 
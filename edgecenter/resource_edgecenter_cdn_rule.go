@@ -925,6 +925,14 @@ func resourceCDNRule() *schema.Resource {
 		UpdateContext: resourceCDNRuleUpdate,
 		DeleteContext: resourceCDNRuleDelete,
 		Description:   "Represent cdn resource rule",
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{
+			{
+				Version: 0,
+				Type:    resourceCDNRuleV0().CoreConfigSchema().ImpliedType(),
+				Upgrade: upgradeCDNOptionsStateV0,
+			},
+		},
 	}
 }
 
@@ -985,6 +993,9 @@ func resourceCDNRuleRead(ctx context.Context, d *schema.ResourceData, m interfac
 
 	result, err := client.Rules().Get(ctx, int64(resourceID), id)
 	if err != nil {
+		if removeIfNotFound(d, "CDN rule", err) {
+			return nil
+		}
 		return diag.FromErr(err)
 	}
 
@@ -994,7 +1005,9 @@ func resourceCDNRuleRead(ctx context.Context, d *schema.ResourceData, m interfac
 	d.Set("origin_group", result.OriginGroup)
 	d.Set("origin_protocol", result.OriginProtocol)
 	d.Set("weight", result.Weight)
-	if err := d.Set("options", locationOptionsToList(result.Options)); err != nil {
+	options := locationOptionsToList(result.Options)
+	keepUpgradedCDNOptions(d.Get("options").([]interface{}), options)
+	if err := d.Set("options", options); err != nil {
 		return diag.FromErr(err)
 	}
 
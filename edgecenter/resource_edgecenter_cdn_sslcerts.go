@@ -91,6 +91,9 @@ func resourceCDNCertRead(ctx context.Context, d *schema.ResourceData, m interfac
 
 	result, err := client.SSLCerts().Get(ctx, id)
 	if err != nil {
+		if removeIfNotFound(d, "CDN certificate", err) {
+			return nil
+		}
 		return diag.FromErr(err)
 	}
 
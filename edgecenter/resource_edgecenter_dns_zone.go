@@ -83,6 +83,9 @@ func resourceDNSZoneRead(ctx context.Context, d *schema.ResourceData, m interfac
 
 	result, err := client.Zone(ctx, zoneName)
 	if err != nil {
+		if removeIfNotFound(d, "DNS zone", err) {
+			return nil
+		}
 		return diag.FromErr(fmt.Errorf("get zone: %w", err))
 	}
 	d.SetId(result.Name)
@@ -103,7 +106,7 @@ func resourceDNSZoneDelete(ctx context.Context, d *schema.ResourceData, m interf
 	client := config.DNSClient
 
 	err := client.DeleteZone(ctx, zoneName)
-	if err != nil {
+	if err != nil && !isNotFound(err) {
 		return diag.FromErr(fmt.Errorf("delete zone: %w", err))
 	}
 	d.SetId("")
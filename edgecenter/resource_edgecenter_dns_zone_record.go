@@ -329,6 +329,9 @@ func resourceDNSZoneRecordRead(ctx context.Context, d *schema.ResourceData, m in
 
 	result, err := client.RRSet(ctx, zone, domain, rType)
 	if err != nil {
+		if removeIfNotFound(d, "DNS zone record", err) {
+			return nil
+		}
 		return diag.FromErr(fmt.Errorf("get zone rrset: %w", err))
 	}
 	id := struct{ Zone, Domain, Type string }{zone, domain, rType} //nolint: musttag
@@ -2,7 +2,6 @@ package edgecenter
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"log"
 	"net"
@@ -12,7 +11,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
-	edgecloud "github.com/Edge-Center/edgecentercloud-go"
 	"github.com/Edge-Center/edgecentercloud-go/edgecenter/floatingip/v1/floatingips"
 	"github.com/Edge-Center/edgecentercloud-go/edgecenter/task/v1/tasks"
 	"github.com/Edge-Center/edgecentercloud-go/edgecenter/utils"
@@ -30,11 +28,16 @@ func resourceFloatingIP() *schema.Resource {
 		ReadContext:   resourceFloatingIPRead,
 		UpdateContext: resourceFloatingIPUpdate,
 		DeleteContext: resourceFloatingIPDelete,
+		CustomizeDiff: customizeDiffMetadataAll,
 		Description: `A floating IP is a static IP address that can be associated with one of your instances or loadbalancers, 
 allowing it to have a static public IP address. The floating IP can be re-associated to any other instance in the same datacenter.`,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(time.Duration(FloatingIPCreateTimeout) * time.Second),
+			Delete: schema.DefaultTimeout(time.Duration(FloatingIPCreateTimeout) * time.Second),
+		},
 		Importer: &schema.ResourceImporter{
 			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-				projectID, regionID, fipID, err := ImportStringParser(d.Id())
+				projectID, regionID, fipID, err := ImportStringParser(meta.(*Config), d.Id())
 				if err != nil {
 					return nil, err
 				}
@@ -48,28 +51,30 @@ allowing it to have a static public IP address. The floating IP can be re-associ
 
 		Schema: map[string]*schema.Schema{
 			"project_id": {
-				Type:         schema.TypeInt,
-				Optional:     true,
-				Description:  "The uuid of the project. Either 'project_id' or 'project_name' must be specified.",
-				ExactlyOneOf: []string{"project_id", "project_name"},
+				Type:          schema.TypeInt,
+				Optional:      true,
+				Computed:      true,
+				Description:   "The ID of the project. Either 'project_id' or 'project_name' must be specified, unless the provider sets a default project.",
+				ConflictsWith: []string{"project_name"},
 			},
 			"project_name": {
-				Type:         schema.TypeString,
-				Optional:     true,
-				Description:  "The name of the project. Either 'project_id' or 'project_name' must be specified.",
-				ExactlyOneOf: []string{"project_id", "project_name"},
+				Type:          schema.TypeString,
+				Optional:      true,
+				Description:   "The name of the project. Either 'project_id' or 'project_name' must be specified, unless the provider sets a default project.",
+				ConflictsWith: []string{"project_id"},
 			},
 			"region_id": {
-				Type:         schema.TypeInt,
-				Optional:     true,
-				Description:  "The uuid of the region. Either 'region_id' or 'region_name' must be specified.",
-				ExactlyOneOf: []string{"region_id", "region_name"},
+				Type:          schema.TypeInt,
+				Optional:      true,
+				Computed:      true,
+				Description:   "The ID of the region. Either 'region_id' or 'region_name' must be specified, unless the provider sets a default region.",
+				ConflictsWith: []string{"region_name"},
 			},
 			"region_name": {
-				Type:         schema.TypeString,
-				Optional:     true,
-				Description:  "The name of the region. Either 'region_id' or 'region_name' must be specified.",
-				ExactlyOneOf: []string{"region_id", "region_name"},
+				Type:          schema.TypeString,
+				Optional:      true,
+				Description:   "The name of the region. Either 'region_id' or 'region_name' must be specified, unless the provider sets a default region.",
+				ConflictsWith: []string{"region_id"},
 			},
 			"floating_ip_address": {
 				Type:        schema.TypeString,
@@ -131,6 +136,14 @@ allowing it to have a static public IP address. The floating IP can be re-associ
 					Type: schema.TypeString,
 				},
 			},
+			"metadata_all": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Description: "A map containing all metadata of the resource, including the provider `default_metadata`.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
 			"metadata_read_only": {
 				Type:        schema.TypeList,
 				Computed:    true,
@@ -160,9 +173,8 @@ func resourceFloatingIPCreate(ctx context.Context, d *schema.ResourceData, m int
 	log.Println("[DEBUG] Start FloatingIP creating")
 	var diags diag.Diagnostics
 	config := m.(*Config)
-	provider := config.Provider
 
-	client, err := CreateClient(provider, d, FloatingIPsPoint, VersionPointV1)
+	client, err := CreateClient(config, d, FloatingIPsPoint, VersionPointV1)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -172,7 +184,7 @@ func resourceFloatingIPCreate(ctx context.Context, d *schema.ResourceData, m int
 		FixedIPAddress: net.ParseIP(d.Get("fixed_ip_address").(string)),
 	}
 
-	if metadataRaw, ok := d.GetOk("metadata_map"); ok {
+	if metadataRaw := metadataWithDefaults(config, d.Get("metadata_map")); len(metadataRaw) > 0 {
 		meta, err := utils.MapInterfaceToMapString(metadataRaw)
 		if err != nil {
 			return diag.FromErr(err)
@@ -186,7 +198,7 @@ func resourceFloatingIPCreate(ctx context.Context, d *schema.ResourceData, m int
 	}
 
 	taskID := results.Tasks[0]
-	floatingIPID, err := tasks.WaitTaskAndReturnResult(client, taskID, true, FloatingIPCreateTimeout, func(task tasks.TaskID) (interface{}, error) {
+	floatingIPID, err := waitTaskAndReturnResult(ctx, client, taskID, d.Timeout(schema.TimeoutCreate), func(task tasks.TaskID) (interface{}, error) {
 		taskInfo, err := tasks.Get(client, string(task)).Extract()
 		if err != nil {
 			return nil, fmt.Errorf("cannot get task with ID: %s. Error: %w", task, err)
@@ -215,19 +227,15 @@ func resourceFloatingIPRead(_ context.Context, d *schema.ResourceData, m interfa
 	log.Println("[DEBUG] Start FloatingIP reading")
 	var diags diag.Diagnostics
 	config := m.(*Config)
-	provider := config.Provider
 
-	client, err := CreateClient(provider, d, FloatingIPsPoint, VersionPointV1)
+	client, err := CreateClient(config, d, FloatingIPsPoint, VersionPointV1)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
 	floatingIP, err := floatingips.Get(client, d.Id()).Extract()
 	if err != nil {
-		var errDefault404 edgecloud.Default404Error
-		if errors.As(err, &errDefault404) {
-			log.Printf("[WARN] Removing floating ip %s because resource doesn't exist anymore", d.Id())
-			d.SetId("")
+		if removeIfNotFound(d, "floating ip", err) {
 			return nil
 		}
 		return diag.FromErr(err)
@@ -248,7 +256,10 @@ func resourceFloatingIPRead(_ context.Context, d *schema.ResourceData, m interfa
 
 	metadataMap, metadataReadOnly := PrepareMetadata(floatingIP.Metadata)
 
-	if err = d.Set("metadata_map", metadataMap); err != nil {
+	if err = d.Set("metadata_map", metadataWithoutDefaults(config, metadataMap, d.Get("metadata_map"))); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("metadata_all", metadataMap); err != nil {
 		return diag.FromErr(err)
 	}
 
@@ -264,9 +275,8 @@ func resourceFloatingIPRead(_ context.Context, d *schema.ResourceData, m interfa
 func resourceFloatingIPUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	log.Println("[DEBUG] Start FloatingIP updating")
 	config := m.(*Config)
-	provider := config.Provider
 
-	client, err := CreateClient(provider, d, FloatingIPsPoint, VersionPointV1)
+	client, err := CreateClient(config, d, FloatingIPsPoint, VersionPointV1)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -296,10 +306,10 @@ func resourceFloatingIPUpdate(ctx context.Context, d *schema.ResourceData, m int
 		d.Set("last_updated", time.Now().Format(time.RFC850))
 	}
 
-	if d.HasChange("metadata_map") {
-		_, nmd := d.GetChange("metadata_map")
+	if d.HasChanges("metadata_map", "metadata_all") {
+		nmd := metadataWithDefaults(config, d.Get("metadata_map"))
 
-		meta, err := utils.MapInterfaceToMapString(nmd.(map[string]interface{}))
+		meta, err := utils.MapInterfaceToMapString(nmd)
 		if err != nil {
 			return diag.Errorf("cannot get metadata. Error: %s", err)
 		}
@@ -313,13 +323,12 @@ func resourceFloatingIPUpdate(ctx context.Context, d *schema.ResourceData, m int
 	return resourceFloatingIPRead(ctx, d, m)
 }
 
-func resourceFloatingIPDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+func resourceFloatingIPDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	log.Println("[DEBUG] Start FloatingIP deleting")
 	var diags diag.Diagnostics
 	config := m.(*Config)
-	provider := config.Provider
 
-	client, err := CreateClient(provider, d, FloatingIPsPoint, VersionPointV1)
+	client, err := CreateClient(config, d, FloatingIPsPoint, VersionPointV1)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -331,13 +340,12 @@ func resourceFloatingIPDelete(_ context.Context, d *schema.ResourceData, m inter
 	}
 
 	taskID := results.Tasks[0]
-	_, err = tasks.WaitTaskAndReturnResult(client, taskID, true, FloatingIPCreateTimeout, func(task tasks.TaskID) (interface{}, error) {
+	_, err = waitTaskAndReturnResult(ctx, client, taskID, d.Timeout(schema.TimeoutDelete), func(task tasks.TaskID) (interface{}, error) {
 		_, err := floatingips.Get(client, id).Extract()
 		if err == nil {
 			return nil, fmt.Errorf("cannot delete floating ip with ID: %s", id)
 		}
-		var errDefault404 edgecloud.Default404Error
-		if errors.As(err, &errDefault404) {
+		if isNotFound(err) {
 			return nil, nil
 		}
 		return nil, fmt.Errorf("extracting FloatingIP resource error: %w", err)
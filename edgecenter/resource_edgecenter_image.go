@@ -0,0 +1,480 @@
+package edgecenter
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/Edge-Center/edgecentercloud-go/edgecenter/image/v1/images"
+	"github.com/Edge-Center/edgecentercloud-go/edgecenter/image/v1/images/types"
+	"github.com/Edge-Center/edgecentercloud-go/edgecenter/task/v1/tasks"
+	"github.com/Edge-Center/edgecentercloud-go/edgecenter/utils"
+	"github.com/Edge-Center/edgecentercloud-go/edgecenter/utils/metadata"
+)
+
+const (
+	ImageCreatingTimeout int = 3600
+	imageDeleting        int = 1200
+	downloadImagePoint       = "downloadimage"
+)
+
+func resourceImage() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceImageCreate,
+		ReadContext:   resourceImageRead,
+		UpdateContext: resourceImageUpdate,
+		DeleteContext: resourceImageDelete,
+		CustomizeDiff: customizeDiffMetadataAll,
+		Description: `A cloud image is a pre-configured virtual machine template that you can use to create new instances.
+The image is either downloaded from an HTTP(S) URL or created from an existing volume.
+As the API doesn't report the source of an image, an imported image isn't replaced for 'url', 'volume_id' or 'cow_format'.`,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(time.Duration(ImageCreatingTimeout) * time.Second),
+			Delete: schema.DefaultTimeout(time.Duration(imageDeleting) * time.Second),
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				projectID, regionID, imageID, err := ImportStringParser(meta.(*Config), d.Id())
+				if err != nil {
+					return nil, err
+				}
+				d.Set("project_id", projectID)
+				d.Set("region_id", regionID)
+				d.SetId(imageID)
+
+				return []*schema.ResourceData{d}, nil
+			},
+		},
+
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:          schema.TypeInt,
+				Optional:      true,
+				Computed:      true,
+				ForceNew:      true,
+				Description:   "The ID of the project. Either 'project_id' or 'project_name' must be specified, unless the provider sets a default project.",
+				ConflictsWith: []string{"project_name"},
+			},
+			"project_name": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				Description:   "The name of the project. Either 'project_id' or 'project_name' must be specified, unless the provider sets a default project.",
+				ConflictsWith: []string{"project_id"},
+			},
+			"region_id": {
+				Type:          schema.TypeInt,
+				Optional:      true,
+				Computed:      true,
+				ForceNew:      true,
+				Description:   "The ID of the region. Either 'region_id' or 'region_name' must be specified, unless the provider sets a default region.",
+				ConflictsWith: []string{"region_name"},
+			},
+			"region_name": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				Description:   "The name of the region. Either 'region_id' or 'region_name' must be specified, unless the provider sets a default region.",
+				ConflictsWith: []string{"region_id"},
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the image.",
+			},
+			"url": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				DiffSuppressFunc: suppressImportedImageSourceDiff,
+				Description:      "(ForceNew) The HTTP(S) URL to download the image from. Either 'url' or 'volume_id' must be specified.",
+				ValidateFunc:     validation.IsURLWithHTTPorHTTPS,
+				ExactlyOneOf:     []string{"url", "volume_id"},
+			},
+			"volume_id": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				DiffSuppressFunc: suppressImportedImageSourceDiff,
+				Description:      "(ForceNew) The ID of the volume to create the image from. Either 'url' or 'volume_id' must be specified.",
+				ExactlyOneOf:     []string{"url", "volume_id"},
+			},
+			"os_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      types.OsLinux.String(),
+				Description:  fmt.Sprintf("The type of the OS in the image. Available values are '%s' and '%s'.", types.OsLinux, types.OsWindows),
+				ValidateFunc: validation.StringInSlice(types.OSType("").StringList(), false),
+			},
+			"os_distro": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "(ForceNew) The distribution of the OS in the image, e.g. Debian, CentOS, Ubuntu. Only used with 'url'.",
+			},
+			"os_version": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "(ForceNew) The version of the OS in the image, e.g. 22.04. Only used with 'url'.",
+			},
+			"ssh_key": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  types.SSHKeyAllow.String(),
+				Description: fmt.Sprintf("Whether cloud-init of instances created from the image accepts an SSH key. Available values are '%s', '%s' and '%s'.",
+					types.SSHKeyAllow, types.SSHKeyDeny, types.SSHKeyRequired),
+				ValidateFunc: validation.StringInSlice(types.SSHKeyType("").StringList(), false),
+			},
+			"hw_machine_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      types.HwMachineI440.String(),
+				Description:  fmt.Sprintf("The machine type of instances created from the image. Available values are '%s' and '%s'.", types.HwMachineI440, types.HwMachineQ35),
+				ValidateFunc: validation.StringInSlice(types.HwMachineType("").StringList(), false),
+			},
+			"hw_firmware_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      types.HwFirmwareBIOS.String(),
+				Description:  fmt.Sprintf("The firmware of instances created from the image. Available values are '%s' and '%s'.", types.HwFirmwareBIOS, types.HwFirmwareUEFI),
+				ValidateFunc: validation.StringInSlice(types.HwFirmwareType("").StringList(), false),
+			},
+			"is_baremetal": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Set to true if the image is meant for baremetal servers.",
+			},
+			"cow_format": {
+				Type:             schema.TypeBool,
+				Optional:         true,
+				ForceNew:         true,
+				DiffSuppressFunc: suppressImportedImageSourceDiff,
+				Description:      "(ForceNew) Set to true to store the image in the copy-on-write format. Only used with 'url'.",
+			},
+			"disk_format": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The disk format of the image, e.g. qcow2 or raw.",
+			},
+			"min_disk": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Minimum disk space (in GB) required to launch an instance using this image.",
+			},
+			"min_ram": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Minimum VM RAM (in MB) required to launch an instance using this image.",
+			},
+			"size": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The size of the image in bytes.",
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The status of the image.",
+			},
+			"last_updated": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The timestamp of the last update (use with update context).",
+			},
+			"metadata_map": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Computed:    true,
+				Description: "A map containing metadata, for example tags.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"metadata_all": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Description: "A map containing all metadata of the resource, including the provider `default_metadata`.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"metadata_read_only": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: `A list of read-only metadata items, e.g. tags.`,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"key": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"value": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"read_only": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// imageInfo is an image with the fields of the API response the SDK doesn't decode.
+type imageInfo struct {
+	images.Image
+	OSType         string `json:"os_type"`
+	SSHKey         string `json:"ssh_key"`
+	HwMachineType  string `json:"hw_machine_type"`
+	HwFirmwareType string `json:"hw_firmware_type"`
+}
+
+// suppressImportedImageSourceDiff keeps an imported image, which has no url or volume_id in its state
+// as the API doesn't report them, from being replaced for the source in the configuration.
+func suppressImportedImageSourceDiff(_, _, _ string, d *schema.ResourceData) bool {
+	if d.Id() == "" {
+		return false
+	}
+	oldURL, _ := d.GetChange("url")
+	oldVolumeID, _ := d.GetChange("volume_id")
+
+	return oldURL.(string) == "" && oldVolumeID.(string) == ""
+}
+
+func resourceImageCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Println("[DEBUG] Start image creating")
+	config := m.(*Config)
+
+	client, err := CreateClient(config, d, ImagesPoint, VersionPointV1)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	meta, err := utils.MapInterfaceToMapString(metadataWithDefaults(config, d.Get("metadata_map")))
+	if err != nil {
+		return diag.Errorf("cannot get metadata. Error: %s", err)
+	}
+
+	isBaremetal := d.Get("is_baremetal").(bool)
+	var results *tasks.TaskResults
+	if url := d.Get("url").(string); url != "" {
+		downloadClient, err := CreateClient(config, d, downloadImagePoint, VersionPointV1)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		opts := images.UploadOpts{
+			Name:           d.Get("name").(string),
+			URL:            url,
+			OsDistro:       d.Get("os_distro").(string),
+			OsVersion:      d.Get("os_version").(string),
+			OSType:         types.OSType(d.Get("os_type").(string)),
+			SSHKey:         types.SSHKeyType(d.Get("ssh_key").(string)),
+			HwMachineType:  types.HwMachineType(d.Get("hw_machine_type").(string)),
+			HwFirmwareType: types.HwFirmwareType(d.Get("hw_firmware_type").(string)),
+			IsBaremetal:    &isBaremetal,
+			CowFormat:      d.Get("cow_format").(bool),
+			Metadata:       meta,
+		}
+		log.Printf("[DEBUG] Image upload options: %+v", opts)
+		results, err = images.Upload(downloadClient, opts).Extract()
+		if err != nil {
+			return diag.Errorf("cannot upload image from %s. Error: %s", url, err)
+		}
+	} else {
+		opts := images.CreateOpts{
+			Name:           d.Get("name").(string),
+			Source:         types.ImageSourceVolume,
+			VolumeID:       d.Get("volume_id").(string),
+			OSType:         types.OSType(d.Get("os_type").(string)),
+			SSHKey:         types.SSHKeyType(d.Get("ssh_key").(string)),
+			HwMachineType:  types.HwMachineType(d.Get("hw_machine_type").(string)),
+			HwFirmwareType: types.HwFirmwareType(d.Get("hw_firmware_type").(string)),
+			IsBaremetal:    &isBaremetal,
+		}
+		log.Printf("[DEBUG] Image create options: %+v", opts)
+		results, err = images.Create(client, opts).Extract()
+		if err != nil {
+			return diag.Errorf("cannot create image from volume %s. Error: %s", opts.VolumeID, err)
+		}
+	}
+
+	taskID := results.Tasks[0]
+	log.Printf("[DEBUG] Task id (%s)", taskID)
+	imageID, err := waitTaskAndReturnResult(ctx, client, taskID, d.Timeout(schema.TimeoutCreate), func(task tasks.TaskID) (interface{}, error) {
+		taskInfo, err := tasks.Get(client, string(task)).Extract()
+		if err != nil {
+			return nil, fmt.Errorf("cannot get task with ID: %s. Error: %w", task, err)
+		}
+		imageID, err := images.ExtractImageIDFromTask(taskInfo)
+		if err != nil {
+			return nil, fmt.Errorf("cannot retrieve image ID from task info: %w", err)
+		}
+		return imageID, nil
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(imageID.(string))
+
+	// the image created from a volume doesn't take metadata in the request
+	if d.Get("url").(string) == "" && len(meta) > 0 {
+		if err := metadata.ResourceMetadataReplace(client, d.Id(), meta).Err; err != nil {
+			return diag.Errorf("cannot set metadata. Error: %s", err)
+		}
+	}
+
+	log.Printf("[DEBUG] Finish image creating (%s)", imageID)
+
+	return resourceImageRead(ctx, d, m)
+}
+
+func resourceImageRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Println("[DEBUG] Start image reading")
+	var diags diag.Diagnostics
+	config := m.(*Config)
+	imageID := d.Id()
+
+	client, err := CreateClient(config, d, ImagesPoint, VersionPointV1)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var image imageInfo
+	if err := images.Get(client, imageID).ExtractInto(&image); err != nil {
+		if removeIfNotFound(d, "image", err) {
+			return nil
+		}
+		return diag.Errorf("cannot get image with ID: %s. Error: %s", imageID, err)
+	}
+
+	d.Set("name", image.Name)
+	d.Set("os_distro", image.OsDistro)
+	d.Set("os_version", image.OsVersion)
+	d.Set("disk_format", image.DiskFormat)
+	d.Set("min_disk", image.MinDisk)
+	d.Set("min_ram", image.MinRAM)
+	d.Set("size", image.Size)
+	d.Set("status", image.Status)
+	for field, value := range map[string]string{
+		"os_type":          image.OSType,
+		"ssh_key":          image.SSHKey,
+		"hw_machine_type":  image.HwMachineType,
+		"hw_firmware_type": image.HwFirmwareType,
+	} {
+		if value != "" {
+			d.Set(field, value)
+		}
+	}
+
+	metadataMap, metadataReadOnly := PrepareMetadata(image.Metadata)
+	if err = d.Set("metadata_map", metadataWithoutDefaults(config, metadataMap, d.Get("metadata_map"))); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("metadata_all", metadataMap); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("metadata_read_only", metadataReadOnly); err != nil {
+		return diag.FromErr(err)
+	}
+
+	fields := []string{"project_id", "region_id", "url", "volume_id", "cow_format", "is_baremetal"}
+	revertState(d, &fields)
+
+	log.Println("[DEBUG] Finish image reading")
+
+	return diags
+}
+
+func resourceImageUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Println("[DEBUG] Start image updating")
+	imageID := d.Id()
+	config := m.(*Config)
+
+	client, err := CreateClient(config, d, ImagesPoint, VersionPointV1)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if d.HasChanges("name", "os_type", "ssh_key", "hw_machine_type", "hw_firmware_type", "is_baremetal") {
+		isBaremetal := d.Get("is_baremetal").(bool)
+		opts := images.UpdateOpts{
+			Name:           d.Get("name").(string),
+			OSType:         types.OSType(d.Get("os_type").(string)),
+			SSHKey:         types.SSHKeyType(d.Get("ssh_key").(string)),
+			HwMachineType:  types.HwMachineType(d.Get("hw_machine_type").(string)),
+			HwFirmwareType: types.HwFirmwareType(d.Get("hw_firmware_type").(string)),
+			IsBaremetal:    &isBaremetal,
+		}
+		if _, err := images.Update(client, imageID, opts).Extract(); err != nil {
+			return diag.Errorf("cannot update image with ID: %s. Error: %s", imageID, err)
+		}
+	}
+
+	if d.HasChanges("metadata_map", "metadata_all") {
+		meta, err := utils.MapInterfaceToMapString(metadataWithDefaults(config, d.Get("metadata_map")))
+		if err != nil {
+			return diag.Errorf("cannot get metadata. Error: %s", err)
+		}
+		if err := metadata.ResourceMetadataReplace(client, imageID, meta).Err; err != nil {
+			return diag.Errorf("cannot update metadata. Error: %s", err)
+		}
+	}
+
+	d.Set("last_updated", time.Now().Format(time.RFC850))
+	log.Println("[DEBUG] Finish image updating")
+
+	return resourceImageRead(ctx, d, m)
+}
+
+func resourceImageDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Println("[DEBUG] Start image deleting")
+	var diags diag.Diagnostics
+	config := m.(*Config)
+	imageID := d.Id()
+
+	client, err := CreateClient(config, d, ImagesPoint, VersionPointV1)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	results, err := images.Delete(client, imageID).Extract()
+	if err != nil {
+		if isNotFound(err) {
+			d.SetId("")
+			return diags
+		}
+		return diag.FromErr(err)
+	}
+	taskID := results.Tasks[0]
+	log.Printf("[DEBUG] Task id (%s)", taskID)
+	err = waitTaskAndProcessResult(ctx, client, taskID, d.Timeout(schema.TimeoutDelete), func(task tasks.TaskID) error {
+		_, err := images.Get(client, imageID).Extract()
+		if err == nil {
+			return fmt.Errorf("cannot delete image with ID: %s", imageID)
+		}
+		if isNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("extracting Image resource error: %w", err)
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	log.Printf("[DEBUG] Finish of image deleting")
+
+	return diags
+}
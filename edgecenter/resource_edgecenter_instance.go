@@ -3,16 +3,16 @@ package edgecenter
 import (
 	"context"
 	"encoding/base64"
-	"errors"
 	"fmt"
 	"log"
+	"reflect"
 	"sort"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
@@ -29,20 +29,89 @@ const (
 	InstanceCreatingTimeout int = 1200
 	InstancePoint               = "instances"
 
-	InstanceVMStateActive  = "active"
-	InstanceVMStateStopped = "stopped"
+	InstanceVMStateActive    = "active"
+	InstanceVMStateStopped   = "stopped"
+	InstanceVMStateSuspended = "suspended"
+	InstanceVMStateRescued   = "rescued"
 )
 
+// volumeSourceAppTemplate is the volume source of an application template, which the SDK doesn't know.
+const volumeSourceAppTemplate types.VolumeSource = "apptemplate"
+
+// instanceVolumeOpts extends instances.CreateVolumeOpts with the apptemplate source.
+type instanceVolumeOpts struct {
+	instances.CreateVolumeOpts `json:",squash"`
+	AppTemplateID              string `json:"apptemplate_id,omitempty"`
+}
+
+// ToInstanceVolumeMap builds a request body of the volume. Volumes of the SDK sources are validated by the SDK.
+func (opts instanceVolumeOpts) ToInstanceVolumeMap() (map[string]interface{}, error) {
+	if opts.Source == volumeSourceAppTemplate {
+		if opts.AppTemplateID == "" {
+			return nil, fmt.Errorf("apptemplate_id is required for a volume with source %s", volumeSourceAppTemplate)
+		}
+		if opts.ImageID != "" || opts.SnapshotID != "" || opts.VolumeID != "" {
+			return nil, fmt.Errorf("a volume with source %s takes no image_id, snapshot_id or volume_id", volumeSourceAppTemplate)
+		}
+	} else {
+		if opts.AppTemplateID != "" {
+			return nil, fmt.Errorf("apptemplate_id is only allowed for a volume with source %s", volumeSourceAppTemplate)
+		}
+		if err := opts.CreateVolumeOpts.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	return edgecloud.BuildRequestBody(opts, "")
+}
+
+// instanceCreateOpts extends instances.CreateOpts with volumes of the apptemplate source.
+type instanceCreateOpts struct {
+	instances.CreateOpts
+	Volumes []instanceVolumeOpts
+}
+
+// ToInstanceCreateMap builds a request body. The SDK builds all of it but the volumes, as it rejects the apptemplate source.
+func (opts instanceCreateOpts) ToInstanceCreateMap() (map[string]interface{}, error) {
+	if len(opts.Volumes) == 0 {
+		return nil, edgecloud.MissingInputError{Argument: "Volumes"}
+	}
+	vols := make([]interface{}, len(opts.Volumes))
+	for i, volume := range opts.Volumes {
+		vol, err := volume.ToInstanceVolumeMap()
+		if err != nil {
+			return nil, err
+		}
+		vols[i] = vol
+	}
+
+	sdkOpts := opts.CreateOpts
+	sdkOpts.Volumes = []instances.CreateVolumeOpts{}
+	mp, err := sdkOpts.ToInstanceCreateMap()
+	if err != nil {
+		return nil, err
+	}
+	mp["volumes"] = vols
+
+	return mp, nil
+}
+
 func resourceInstance() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceInstanceCreate,
 		ReadContext:   resourceInstanceRead,
 		UpdateContext: resourceInstanceUpdate,
 		DeleteContext: resourceInstanceDelete,
+		CustomizeDiff: resourceInstanceCustomizeDiff,
 		Description:   "A cloud instance is a virtual machine in a cloud environment.",
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(time.Duration(InstanceCreatingTimeout) * time.Second),
+			Update: schema.DefaultTimeout(time.Duration(InstanceCreatingTimeout) * time.Second),
+			Delete: schema.DefaultTimeout(time.Duration(InstanceDeleting) * time.Second),
+		},
 		Importer: &schema.ResourceImporter{
 			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-				projectID, regionID, InstanceID, err := ImportStringParser(d.Id())
+				projectID, regionID, InstanceID, err := ImportStringParser(meta.(*Config), d.Id())
 				if err != nil {
 					return nil, err
 				}
@@ -56,28 +125,30 @@ func resourceInstance() *schema.Resource {
 
 		Schema: map[string]*schema.Schema{
 			"project_id": {
-				Type:         schema.TypeInt,
-				Optional:     true,
-				Description:  "The uuid of the project. Either 'project_id' or 'project_name' must be specified.",
-				ExactlyOneOf: []string{"project_id", "project_name"},
+				Type:          schema.TypeInt,
+				Optional:      true,
+				Computed:      true,
+				Description:   "The ID of the project. Either 'project_id' or 'project_name' must be specified, unless the provider sets a default project.",
+				ConflictsWith: []string{"project_name"},
 			},
 			"project_name": {
-				Type:         schema.TypeString,
-				Optional:     true,
-				Description:  "The name of the project. Either 'project_id' or 'project_name' must be specified.",
-				ExactlyOneOf: []string{"project_id", "project_name"},
+				Type:          schema.TypeString,
+				Optional:      true,
+				Description:   "The name of the project. Either 'project_id' or 'project_name' must be specified, unless the provider sets a default project.",
+				ConflictsWith: []string{"project_id"},
 			},
 			"region_id": {
-				Type:         schema.TypeInt,
-				Optional:     true,
-				Description:  "The uuid of the region. Either 'region_id' or 'region_name' must be specified.",
-				ExactlyOneOf: []string{"region_id", "region_name"},
+				Type:          schema.TypeInt,
+				Optional:      true,
+				Computed:      true,
+				Description:   "The ID of the region. Either 'region_id' or 'region_name' must be specified, unless the provider sets a default region.",
+				ConflictsWith: []string{"region_name"},
 			},
 			"region_name": {
-				Type:         schema.TypeString,
-				Optional:     true,
-				Description:  "The name of the region. Either 'region_id' or 'region_name' must be specified.",
-				ExactlyOneOf: []string{"region_id", "region_name"},
+				Type:          schema.TypeString,
+				Optional:      true,
+				Description:   "The name of the region. Either 'region_id' or 'region_name' must be specified, unless the provider sets a default region.",
+				ConflictsWith: []string{"region_id"},
 			},
 			"name": {
 				Type:        schema.TypeString,
@@ -107,7 +178,7 @@ func resourceInstance() *schema.Resource {
 				Type:        schema.TypeSet,
 				Required:    true,
 				Set:         volumeUniqueID,
-				Description: "A set defining the volumes to be attached to the instance.",
+				Description: "A set defining the volumes to be attached to the instance. Volumes created from an image, a snapshot or blank are created together with the instance and can't be changed without recreating it. Volumes attached with edgecenter_volume_attachment are not part of this set. As the instance can't tell those from volumes attached outside of Terraform, volumes missing from this set are ignored rather than reported as drift, unless the instance is imported.",
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"name": {
@@ -118,13 +189,14 @@ func resourceInstance() *schema.Resource {
 						"source": {
 							Type:        schema.TypeString,
 							Required:    true,
-							Description: "Currently available only 'existing-volume' value",
+							Description: fmt.Sprintf("The source of the volume. Available values are '%s' to attach volume_id, '%s' to create it from image_id, '%s' to create it from snapshot_id, '%s' to create a blank one and '%s' to create it from the application template apptemplate_id.", types.ExistingVolume, types.Image, types.Snapshot, types.NewVolume, volumeSourceAppTemplate),
 							ValidateDiagFunc: func(val interface{}, key cty.Path) diag.Diagnostics {
 								v := val.(string)
-								if types.VolumeSource(v) == types.ExistingVolume {
-									return diag.Diagnostics{}
+								sources := append(types.VolumeSource(v).StringList(), volumeSourceAppTemplate.String())
+								if types.VolumeSource(v) != volumeSourceAppTemplate && types.VolumeSource(v).IsValid() != nil {
+									return diag.Errorf("wrong source type %s, available values are '%s'", v, strings.Join(sources, "', '"))
 								}
-								return diag.Errorf("wrong source type %s, now available values is '%s'", v, types.ExistingVolume)
+								return diag.Diagnostics{}
 							},
 						},
 						"boot_index": {
@@ -138,8 +210,19 @@ func resourceInstance() *schema.Resource {
 							Description: "The type of volume to create. Valid values are 'ssd_hiiops', 'standard', 'cold', and 'ultra'. Defaults to 'standard'.",
 						},
 						"image_id": {
-							Type:     schema.TypeString,
-							Optional: true,
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The ID of the image to create the volume from. Required if source is 'image'.",
+						},
+						"snapshot_id": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The ID of the snapshot to create the volume from. Required if source is 'snapshot'.",
+						},
+						"apptemplate_id": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The ID of the application template to create the volume from. Required if source is 'apptemplate'. Settings of the template are given in 'configuration'.",
 						},
 						"size": {
 							Type:        schema.TypeInt,
@@ -148,22 +231,25 @@ func resourceInstance() *schema.Resource {
 							Description: "The size of the volume, specified in gigabytes (GB).",
 						},
 						"volume_id": {
-							Type:     schema.TypeString,
-							Optional: true,
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The ID of the existing volume to attach. Required if source is 'existing-volume'.",
 						},
 						"attachment_tag": {
 							Type:     schema.TypeString,
 							Optional: true,
 						},
 						"id": {
-							Type:     schema.TypeString,
-							Optional: true,
-							Computed: true,
+							Type:        schema.TypeString,
+							Optional:    true,
+							Computed:    true,
+							Description: "The ID of the volume, also of the volumes created together with the instance.",
 						},
 						"delete_on_termination": {
-							Type:     schema.TypeBool,
-							Optional: true,
-							Computed: true,
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Computed:    true,
+							Description: "Whether the volume is deleted together with the instance.",
 						},
 					},
 				},
@@ -171,7 +257,7 @@ func resourceInstance() *schema.Resource {
 			"interface": {
 				Type:        schema.TypeList,
 				Required:    true,
-				Description: "A list defining the network interfaces to be attached to the instance.",
+				Description: "A list defining the network interfaces to be attached to the instance. Interfaces attached with edgecenter_instance_interface are not part of this list.",
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"type": {
@@ -294,6 +380,14 @@ func resourceInstance() *schema.Resource {
 					Type: schema.TypeString,
 				},
 			},
+			"metadata_all": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Description: "A map containing all metadata of the instance, including the provider `default_metadata`. Not set when the deprecated `metadata` is used.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
 			"configuration": {
 				Type:     schema.TypeList,
 				Optional: true,
@@ -320,10 +414,13 @@ from a template (marketplace), e.g. {"gitlab_external_url": "https://gitlab/..."
 				ConflictsWith: []string{"user_data"},
 			},
 			"user_data": {
-				Type:          schema.TypeString,
-				Optional:      true,
-				ConflictsWith: []string{"userdata"},
-				Description:   "A field for specifying user data to be used for configuring the instance at launch time.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				ConflictsWith:    []string{"userdata"},
+				ValidateDiagFunc: validateUserData,
+				Description: `A field for specifying user data to be used for configuring the instance at launch time.
+User data base64-encoded already, e.g. by edgecenter_cloudinit_config, is passed as is when it decodes to a gzip
+or cloud-init document, one starting with a header like '#cloud-config', '#!' or 'Content-Type:'.`,
 			},
 			"allow_app_ports": {
 				Type:        schema.TypeBool,
@@ -346,9 +443,28 @@ from a template (marketplace), e.g. {"gitlab_external_url": "https://gitlab/..."
 				Type:     schema.TypeString,
 				Optional: true,
 				Computed: true,
-				Description: fmt.Sprintf(`The current virtual machine state of the instance, 
-allowing you to start or stop the VM. Possible values are %s and %s.`, InstanceVMStateStopped, InstanceVMStateActive),
-				ValidateFunc: validation.StringInSlice([]string{InstanceVMStateActive, InstanceVMStateStopped}, true),
+				Description: fmt.Sprintf(`The current virtual machine state of the instance,
+allowing you to start, stop, suspend or rescue the VM. Possible values are %s, %s, %s and %s.
+An instance is stopped, suspended or rescued from %s only. A rescued instance boots from a rescue image with its volumes attached,
+for example to repair a broken bootloader.`,
+					InstanceVMStateStopped, InstanceVMStateActive, InstanceVMStateSuspended, InstanceVMStateRescued, InstanceVMStateActive),
+				ValidateFunc: validation.StringInSlice([]string{
+					InstanceVMStateActive, InstanceVMStateStopped, InstanceVMStateSuspended, InstanceVMStateRescued,
+				}, true),
+			},
+			"reboot_trigger": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Description: `An arbitrary map of values which reboots the instance when changed,
+for example {kernel = "5.15.0-91"}. Nothing happens on creation or when the instance is not active.`,
+				Elem: &schema.Schema{Type: schema.TypeString},
+			},
+			"hard_reboot_trigger": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Description: `An arbitrary map of values which power cycles the instance when changed, like 'reboot_trigger'.
+It takes precedence when both triggers change at once.`,
+				Elem: &schema.Schema{Type: schema.TypeString},
 			},
 			"addresses": {
 				Type:        schema.TypeList,
@@ -385,6 +501,14 @@ allowing you to start or stop the VM. Possible values are %s and %s.`, InstanceV
 				Description: "The timestamp of the last update (use with update context).",
 			},
 		},
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{
+			{
+				Version: 0,
+				Type:    resourceInstanceV0().CoreConfigSchema().ImpliedType(),
+				Upgrade: upgradeComputeStateV0,
+			},
+		},
 	}
 }
 
@@ -392,19 +516,19 @@ func resourceInstanceCreate(ctx context.Context, d *schema.ResourceData, m inter
 	log.Println("[DEBUG] Start Instance creating")
 	var diags diag.Diagnostics
 	config := m.(*Config)
-	provider := config.Provider
 
-	clientV1, err := CreateClient(provider, d, InstancePoint, VersionPointV1)
+	clientV1, err := CreateClient(config, d, InstancePoint, VersionPointV1)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	clientV2, err := CreateClient(provider, d, InstancePoint, VersionPointV2)
+	clientV2, err := CreateClient(config, d, InstancePoint, VersionPointV2)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	createOpts := instances.CreateOpts{
+	var createOpts instanceCreateOpts
+	createOpts.CreateOpts = instances.CreateOpts{
 		Flavor:         d.Get("flavor_id").(string),
 		SecurityGroups: []edgecloud.ItemID{},
 		Keypair:        d.Get("keypair_name").(string),
@@ -415,7 +539,7 @@ func resourceInstanceCreate(ctx context.Context, d *schema.ResourceData, m inter
 	}
 
 	if userData, ok := d.GetOk("user_data"); ok {
-		createOpts.UserData = base64.StdEncoding.EncodeToString([]byte(userData.(string)))
+		createOpts.UserData = encodeUserData(userData.(string))
 	} else if userData, ok := d.GetOk("userdata"); ok {
 		createOpts.UserData = base64.StdEncoding.EncodeToString([]byte(userData.(string)))
 	}
@@ -464,8 +588,8 @@ func resourceInstanceCreate(ctx context.Context, d *schema.ResourceData, m inter
 			}
 			createOpts.Metadata = &md
 		}
-	} else if metadataRaw, ok := d.GetOk("metadata_map"); ok {
-		md := extractMetadataMap(metadataRaw.(map[string]interface{}))
+	} else if metadataRaw := metadataWithDefaults(config, d.Get("metadata_map")); len(metadataRaw) > 0 {
+		md := extractMetadataMap(metadataRaw)
 		createOpts.Metadata = &md
 	}
 
@@ -486,24 +610,45 @@ func resourceInstanceCreate(ctx context.Context, d *schema.ResourceData, m inter
 
 	taskID := results.Tasks[0]
 	log.Printf("[DEBUG] Task id (%s)", taskID)
-	InstanceID, err := tasks.WaitTaskAndReturnResult(clientV1, taskID, true, InstanceCreatingTimeout, func(task tasks.TaskID) (interface{}, error) {
+	taskResult, err := waitTaskAndReturnResult(ctx, clientV1, taskID, d.Timeout(schema.TimeoutCreate), func(task tasks.TaskID) (interface{}, error) {
 		taskInfo, err := tasks.Get(clientV1, string(task)).Extract()
 		if err != nil {
 			return nil, fmt.Errorf("cannot get task with ID: %s. Error: %w", task, err)
 		}
-		Instance, err := instances.ExtractInstanceIDFromTask(taskInfo)
-		if err != nil {
+		var result instances.InstanceTaskResult
+		if err := edgecloud.NativeMapToStruct(taskInfo.CreatedResources, &result); err != nil {
 			return nil, fmt.Errorf("cannot retrieve Instance ID from task info: %w", err)
 		}
-		return Instance, nil
+		if len(result.Instances) == 0 {
+			return nil, fmt.Errorf("cannot retrieve Instance ID from task info: no instances created")
+		}
+		return result, nil
 	},
 	)
-	log.Printf("[DEBUG] Instance id (%s)", InstanceID)
 	if err != nil {
 		return diag.FromErr(err)
 	}
+	created := taskResult.(instances.InstanceTaskResult)
+	InstanceID := created.Instances[0]
+	log.Printf("[DEBUG] Instance id (%s)", InstanceID)
+
+	d.SetId(InstanceID)
 
-	d.SetId(InstanceID.(string))
+	volumesClient, err := CreateClient(config, d, VolumesPoint, VersionPointV1)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	createdVolumes := make([]volumes.Volume, 0, len(created.Volumes))
+	for _, volumeID := range created.Volumes {
+		volume, err := volumes.Get(volumesClient, volumeID).Extract()
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		createdVolumes = append(createdVolumes, *volume)
+	}
+	if err := setCreatedVolumeIDs(d, currentVols, createdVolumes); err != nil {
+		return diag.FromErr(err)
+	}
 	resourceInstanceRead(ctx, d, m)
 
 	log.Printf("[DEBUG] Finish Instance creating (%s)", InstanceID)
@@ -515,26 +660,22 @@ func resourceInstanceRead(_ context.Context, d *schema.ResourceData, m interface
 	log.Println("[DEBUG] Start Instance reading")
 	var diags diag.Diagnostics
 	config := m.(*Config)
-	provider := config.Provider
 	instanceID := d.Id()
 	log.Printf("[DEBUG] Instance id = %s", instanceID)
 
-	client, err := CreateClient(provider, d, InstancePoint, VersionPointV1)
+	client, err := CreateClient(config, d, InstancePoint, VersionPointV1)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	clientV2, err := CreateClient(provider, d, InstancePoint, VersionPointV2)
+	clientV2, err := CreateClient(config, d, InstancePoint, VersionPointV2)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
 	instance, err := instances.Get(client, instanceID).Extract()
 	if err != nil {
-		var errDefault404 edgecloud.Default404Error
-		if errors.As(err, &errDefault404) {
-			log.Printf("[WARN] Removing instance %s because resource doesn't exist anymore", d.Id())
-			d.SetId("")
+		if removeIfNotFound(d, "instance", err) {
 			return nil
 		}
 		return diag.FromErr(err)
@@ -557,8 +698,12 @@ func resourceInstanceRead(_ context.Context, d *schema.ResourceData, m interface
 	extVolumes := make([]interface{}, 0, len(instance.Volumes))
 	for _, vol := range instance.Volumes {
 		v, ok := currentVolumes[vol.ID]
-		// todo fix it
 		if !ok {
+			// volumes attached by edgecenter_volume_attachment are managed there, only an import takes over all volumes.
+			// Volumes attached out of band look the same in the API, so they are skipped as well and don't show up as drift.
+			if len(currentVolumes) > 0 {
+				continue
+			}
 			v = make(map[string]interface{})
 			v["volume_id"] = vol.ID
 			v["source"] = types.ExistingVolume.String()
@@ -595,6 +740,7 @@ func resourceInstanceRead(_ context.Context, d *schema.ResourceData, m interface
 		return diag.FromErr(err)
 	}
 
+	matches := matchInstanceInterfaces(interfacesListAPI, interfacesListExtracted)
 	var interfacesList []interface{}
 	for order, iFace := range interfacesListAPI {
 		if len(iFace.IPAssignments) == 0 {
@@ -602,16 +748,16 @@ func resourceInstanceRead(_ context.Context, d *schema.ResourceData, m interface
 		}
 
 		portID := iFace.PortID
-		for _, assignment := range iFace.IPAssignments {
+		for n, assignment := range iFace.IPAssignments {
 			subnetID := assignment.SubnetID
 			ipAddress := assignment.IPAddress.String()
 
 			var interfaceOpts instances.InterfaceOpts
-			for _, interfaceExtracted := range interfacesListExtracted {
-				if interfaceExtracted.SubnetID == subnetID || interfaceExtracted.IPAddress == ipAddress || interfaceExtracted.PortID == portID {
-					interfaceOpts = interfaceExtracted
-					break
-				}
+			// interfaces attached by edgecenter_instance_interface are managed there, only an import takes over all interfaces
+			if block := matches[order][n]; block >= 0 {
+				interfaceOpts = interfacesListExtracted[block]
+			} else if len(interfacesListExtracted) > 0 {
+				continue
 			}
 
 			i := make(map[string]interface{})
@@ -659,32 +805,30 @@ func resourceInstanceRead(_ context.Context, d *schema.ResourceData, m interface
 	} else {
 		metadata := d.Get("metadata_map").(map[string]interface{})
 		newMetadata := make(map[string]interface{}, len(metadata))
-		for k := range metadata {
+		allMetadata := make(map[string]interface{}, len(metadata)+len(config.DefaultMetadata))
+		for k := range metadataWithDefaults(config, metadata) {
 			md, err := edgecloudMeta.ResourceMetadataGet(clientV2, instanceID, k).Extract()
 			if err != nil {
+				// keys of default_metadata may be missing on instances created before it was set
+				if _, ok := metadata[k]; !ok && isNotFound(err) {
+					continue
+				}
 				return diag.Errorf("cannot get metadata with key: %s. Error: %s", instanceID, err)
 			}
-			newMetadata[k] = md.Value
+			if _, ok := metadata[k]; ok {
+				newMetadata[k] = md.Value
+			}
+			allMetadata[k] = md.Value
 		}
 		if err := d.Set("metadata_map", newMetadata); err != nil {
 			return diag.FromErr(err)
 		}
-	}
-
-	addresses := []map[string][]map[string]string{}
-	for _, data := range instance.Addresses {
-		d := map[string][]map[string]string{}
-		netd := make([]map[string]string, len(data))
-		for i, iaddr := range data {
-			ndata := make(map[string]string, 2)
-			ndata["type"] = iaddr.Type.String()
-			ndata["addr"] = iaddr.Address.String()
-			netd[i] = ndata
+		if err := d.Set("metadata_all", allMetadata); err != nil {
+			return diag.FromErr(err)
 		}
-		d["net"] = netd
-		addresses = append(addresses, d)
 	}
-	if err := d.Set("addresses", addresses); err != nil {
+
+	if err := d.Set("addresses", instanceAddressesList(instance.Addresses)); err != nil {
 		return diag.FromErr(err)
 	}
 
@@ -698,8 +842,7 @@ func resourceInstanceUpdate(ctx context.Context, d *schema.ResourceData, m inter
 	instanceID := d.Id()
 	log.Printf("[DEBUG] Instance id = %s", instanceID)
 	config := m.(*Config)
-	provider := config.Provider
-	client, err := CreateClient(provider, d, InstancePoint, VersionPointV1)
+	client, err := CreateClient(config, d, InstancePoint, VersionPointV1)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -725,7 +868,7 @@ func resourceInstanceUpdate(ctx context.Context, d *schema.ResourceData, m inter
 		}
 		taskID := results.Tasks[0]
 		log.Printf("[DEBUG] Task id (%s)", taskID)
-		taskState, err := tasks.WaitTaskAndReturnResult(client, taskID, true, InstanceCreatingTimeout, func(task tasks.TaskID) (interface{}, error) {
+		taskState, err := waitTaskAndReturnResult(ctx, client, taskID, d.Timeout(schema.TimeoutUpdate), func(task tasks.TaskID) (interface{}, error) {
 			taskInfo, err := tasks.Get(client, string(task)).Extract()
 			if err != nil {
 				return nil, fmt.Errorf("cannot get task with ID: %s. Error: %w", task, err)
@@ -768,19 +911,23 @@ func resourceInstanceUpdate(ctx context.Context, d *schema.ResourceData, m inter
 				return diag.Errorf("cannot create metadata. Error: %s", err)
 			}
 		}
-	} else if d.HasChange("metadata_map") {
-		omd, nmd := d.GetChange("metadata_map")
-		if len(omd.(map[string]interface{})) > 0 {
-			for k := range omd.(map[string]interface{}) {
-				err := instances.MetadataDelete(client, instanceID, k).Err
-				if err != nil {
-					return diag.Errorf("cannot delete metadata key: %s. Error: %s", k, err)
-				}
+	} else if d.HasChanges("metadata_map", "metadata_all") {
+		omd, _ := d.GetChange("metadata_map")
+		oamd, _ := d.GetChange("metadata_all")
+		oldMetadata := omd.(map[string]interface{})
+		for k, v := range oamd.(map[string]interface{}) {
+			oldMetadata[k] = v
+		}
+		nmd := metadataWithDefaults(config, d.Get("metadata_map"))
+		for k := range oldMetadata {
+			err := instances.MetadataDelete(client, instanceID, k).Err
+			if err != nil && !isNotFound(err) {
+				return diag.Errorf("cannot delete metadata key: %s. Error: %s", k, err)
 			}
 		}
-		if len(nmd.(map[string]interface{})) > 0 {
+		if len(nmd) > 0 {
 			var MetaData []instances.MetadataOpts
-			for k, v := range nmd.(map[string]interface{}) {
+			for k, v := range nmd {
 				md := instances.MetadataOpts{
 					Key:   k,
 					Value: v.(string),
@@ -814,7 +961,7 @@ func resourceInstanceUpdate(ctx context.Context, d *schema.ResourceData, m inter
 				sgsIDsNew := getSecurityGroupsIDs(iNew["security_groups"].([]interface{}))
 				if len(sgsIDsOld) > 0 || len(sgsIDsNew) > 0 {
 					portID := iOld["port_id"].(string)
-					sgClient, err := CreateClient(provider, d, SecurityGroupPoint, VersionPointV1)
+					sgClient, err := CreateClient(config, d, SecurityGroupPoint, VersionPointV1)
 					if err != nil {
 						return diag.FromErr(err)
 					}
@@ -830,10 +977,10 @@ func resourceInstanceUpdate(ctx context.Context, d *schema.ResourceData, m inter
 
 				differentFields := getMapDifference(iOld, iNew, []string{"security_groups"})
 				if len(differentFields) > 0 {
-					if err := detachInterfaceFromInstance(client, instanceID, iOld); err != nil {
+					if err := detachInterfaceFromInstance(ctx, client, instanceID, iOld, d.Timeout(schema.TimeoutUpdate)); err != nil {
 						return diag.FromErr(err)
 					}
-					if err := attachInterfaceToInstance(client, instanceID, iNew); err != nil {
+					if err := attachInterfaceToInstance(ctx, client, instanceID, iNew, d.Timeout(schema.TimeoutUpdate)); err != nil {
 						return diag.FromErr(err)
 					}
 				}
@@ -849,7 +996,7 @@ func resourceInstanceUpdate(ctx context.Context, d *schema.ResourceData, m inter
 				sgsIDsNew := getSecurityGroupsIDs(iNew["security_groups"].([]interface{}))
 				if len(sgsIDsOld) > 0 || len(sgsIDsNew) > 0 {
 					portID := iOld["port_id"].(string)
-					clientSG, err := CreateClient(provider, d, SecurityGroupPoint, VersionPointV1)
+					clientSG, err := CreateClient(config, d, SecurityGroupPoint, VersionPointV1)
 					if err != nil {
 						return diag.FromErr(err)
 					}
@@ -866,10 +1013,10 @@ func resourceInstanceUpdate(ctx context.Context, d *schema.ResourceData, m inter
 
 				differentFields := getMapDifference(iOld, iNew, []string{"security_groups"})
 				if len(differentFields) > 0 {
-					if err := detachInterfaceFromInstance(client, instanceID, iOld); err != nil {
+					if err := detachInterfaceFromInstance(ctx, client, instanceID, iOld, d.Timeout(schema.TimeoutUpdate)); err != nil {
 						return diag.FromErr(err)
 					}
-					if err := attachInterfaceToInstance(client, instanceID, iNew); err != nil {
+					if err := attachInterfaceToInstance(ctx, client, instanceID, iNew, d.Timeout(schema.TimeoutUpdate)); err != nil {
 						return diag.FromErr(err)
 					}
 				}
@@ -877,7 +1024,7 @@ func resourceInstanceUpdate(ctx context.Context, d *schema.ResourceData, m inter
 
 			for _, item := range ifsNewSlice[len(ifsOldSlice):] {
 				iNew := item.(map[string]interface{})
-				if err := attachInterfaceToInstance(client, instanceID, iNew); err != nil {
+				if err := attachInterfaceToInstance(ctx, client, instanceID, iNew, d.Timeout(schema.TimeoutUpdate)); err != nil {
 					return diag.FromErr(err)
 				}
 			}
@@ -892,7 +1039,7 @@ func resourceInstanceUpdate(ctx context.Context, d *schema.ResourceData, m inter
 				sgsIDsNew := getSecurityGroupsIDs(iNew["security_groups"].([]interface{}))
 				if len(sgsIDsOld) > 0 || len(sgsIDsNew) > 0 {
 					portID := iOld["port_id"].(string)
-					clientSG, err := CreateClient(provider, d, SecurityGroupPoint, VersionPointV1)
+					clientSG, err := CreateClient(config, d, SecurityGroupPoint, VersionPointV1)
 					if err != nil {
 						return diag.FromErr(err)
 					}
@@ -909,10 +1056,10 @@ func resourceInstanceUpdate(ctx context.Context, d *schema.ResourceData, m inter
 
 				differentFields := getMapDifference(iOld, iNew, []string{"security_groups"})
 				if len(differentFields) > 0 {
-					if err := detachInterfaceFromInstance(client, instanceID, iOld); err != nil {
+					if err := detachInterfaceFromInstance(ctx, client, instanceID, iOld, d.Timeout(schema.TimeoutUpdate)); err != nil {
 						return diag.FromErr(err)
 					}
-					if err := attachInterfaceToInstance(client, instanceID, iNew); err != nil {
+					if err := attachInterfaceToInstance(ctx, client, instanceID, iNew, d.Timeout(schema.TimeoutUpdate)); err != nil {
 						return diag.FromErr(err)
 					}
 				}
@@ -920,7 +1067,7 @@ func resourceInstanceUpdate(ctx context.Context, d *schema.ResourceData, m inter
 
 			for _, item := range ifsOldSlice[len(ifsNewSlice):] {
 				iOld := item.(map[string]interface{})
-				if err := detachInterfaceFromInstance(client, instanceID, iOld); err != nil {
+				if err := detachInterfaceFromInstance(ctx, client, instanceID, iOld, d.Timeout(schema.TimeoutUpdate)); err != nil {
 					return diag.FromErr(err)
 				}
 			}
@@ -931,14 +1078,14 @@ func resourceInstanceUpdate(ctx context.Context, d *schema.ResourceData, m inter
 		oldSGRaw, newSGRaw := d.GetChange("server_group")
 		oldSGID, newSGID := oldSGRaw.(string), newSGRaw.(string)
 
-		clientSG, err := CreateClient(provider, d, ServerGroupsPoint, VersionPointV1)
+		clientSG, err := CreateClient(config, d, ServerGroupsPoint, VersionPointV1)
 		if err != nil {
 			return diag.FromErr(err)
 		}
 
 		// delete old server group
 		if oldSGID != "" {
-			err := deleteServerGroup(clientSG, client, instanceID, oldSGID)
+			err := deleteServerGroup(ctx, clientSG, client, instanceID, oldSGID, d.Timeout(schema.TimeoutUpdate))
 			if err != nil {
 				return diag.FromErr(err)
 			}
@@ -946,7 +1093,7 @@ func resourceInstanceUpdate(ctx context.Context, d *schema.ResourceData, m inter
 
 		// add new server group if needed
 		if newSGID != "" {
-			err := addServerGroup(clientSG, client, instanceID, newSGID)
+			err := addServerGroup(ctx, clientSG, client, instanceID, newSGID, d.Timeout(schema.TimeoutUpdate))
 			if err != nil {
 				return diag.FromErr(err)
 			}
@@ -954,7 +1101,7 @@ func resourceInstanceUpdate(ctx context.Context, d *schema.ResourceData, m inter
 	}
 
 	if d.HasChange("volume") {
-		vClient, err := CreateClient(provider, d, VolumesPoint, VersionPointV1)
+		vClient, err := CreateClient(config, d, VolumesPoint, VersionPointV1)
 		if err != nil {
 			return diag.FromErr(err)
 		}
@@ -986,38 +1133,27 @@ func resourceInstanceUpdate(ctx context.Context, d *schema.ResourceData, m inter
 	}
 
 	if d.HasChange("vm_state") {
-		state := d.Get("vm_state").(string)
-		switch state {
-		case InstanceVMStateActive:
-			if _, err := instances.Start(client, instanceID).Extract(); err != nil {
-				return diag.FromErr(err)
-			}
-			startStateConf := &retry.StateChangeConf{
-				Target:     []string{InstanceVMStateActive},
-				Refresh:    ServerV2StateRefreshFunc(client, instanceID),
-				Timeout:    d.Timeout(schema.TimeoutCreate),
-				Delay:      10 * time.Second,
-				MinTimeout: 3 * time.Second,
-			}
-			_, err = startStateConf.WaitForStateContext(ctx)
-			if err != nil {
-				return diag.Errorf("Error waiting for instance (%s) to become active: %s", d.Id(), err)
-			}
-		case InstanceVMStateStopped:
-			if _, err := instances.Stop(client, instanceID).Extract(); err != nil {
+		oldState, newState := d.GetChange("vm_state")
+		action, err := instanceStateAction(oldState.(string), newState.(string))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if action != nil {
+			if err := runInstanceAction(ctx, client, instanceID, *action, newState.(string), d.Timeout(schema.TimeoutUpdate)); err != nil {
 				return diag.FromErr(err)
 			}
-			stopStateConf := &retry.StateChangeConf{
-				Target:     []string{InstanceVMStateStopped},
-				Refresh:    ServerV2StateRefreshFunc(client, instanceID),
-				Timeout:    d.Timeout(schema.TimeoutCreate),
-				Delay:      10 * time.Second,
-				MinTimeout: 3 * time.Second,
-			}
-			_, err = stopStateConf.WaitForStateContext(ctx)
-			if err != nil {
-				return diag.Errorf("Error waiting for instance (%s) to become inactive(stopped): %s", d.Id(), err)
-			}
+		}
+	}
+
+	if d.HasChanges("reboot_trigger", "hard_reboot_trigger") {
+		action := instanceActionReboot
+		if d.HasChange("hard_reboot_trigger") {
+			action = instanceActionPowerCycle
+		}
+		if state := d.Get("vm_state").(string); state != InstanceVMStateActive {
+			log.Printf("[DEBUG] Skip %s of instance %s in state %s", action.name, instanceID, state)
+		} else if err := runInstanceAction(ctx, client, instanceID, action, InstanceVMStateActive, d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return diag.FromErr(err)
 		}
 	}
 
@@ -1027,33 +1163,39 @@ func resourceInstanceUpdate(ctx context.Context, d *schema.ResourceData, m inter
 	return resourceInstanceRead(ctx, d, m)
 }
 
-func resourceInstanceDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+func resourceInstanceDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	log.Println("[DEBUG] Start Instance deleting")
 	var diags diag.Diagnostics
 	config := m.(*Config)
-	provider := config.Provider
 	instanceID := d.Id()
 	log.Printf("[DEBUG] Instance id = %s", instanceID)
 
-	client, err := CreateClient(provider, d, InstancePoint, VersionPointV1)
+	client, err := CreateClient(config, d, InstancePoint, VersionPointV1)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
 	var delOpts instances.DeleteOpts
+	for _, volume := range d.Get("volume").(*schema.Set).List() {
+		vol := volume.(map[string]interface{})
+		if id, _ := vol["id"].(string); id != "" && vol["delete_on_termination"].(bool) {
+			delOpts.Volumes = append(delOpts.Volumes, id)
+		}
+	}
+
+	log.Printf("[DEBUG] Instance delete options: %+v", delOpts)
 	results, err := instances.Delete(client, instanceID, delOpts).Extract()
 	if err != nil {
 		return diag.FromErr(err)
 	}
 	taskID := results.Tasks[0]
 	log.Printf("[DEBUG] Task id (%s)", taskID)
-	_, err = tasks.WaitTaskAndReturnResult(client, taskID, true, InstanceDeleting, func(task tasks.TaskID) (interface{}, error) {
+	_, err = waitTaskAndReturnResult(ctx, client, taskID, d.Timeout(schema.TimeoutDelete), func(task tasks.TaskID) (interface{}, error) {
 		_, err := instances.Get(client, instanceID).Extract()
 		if err == nil {
 			return nil, fmt.Errorf("cannot delete instance with ID: %s", instanceID)
 		}
-		var errDefault404 edgecloud.Default404Error
-		if errors.As(err, &errDefault404) {
+		if isNotFound(err) {
 			return nil, nil
 		}
 		return nil, fmt.Errorf("extracting Instance resource error: %w", err)
@@ -1067,3 +1209,29 @@ func resourceInstanceDelete(_ context.Context, d *schema.ResourceData, m interfa
 
 	return diags
 }
+
+// resourceInstanceCustomizeDiff recreates the instance when the volumes created together with it change,
+// rejects vm_state changes the API has no action for and plans metadata_all unless the deprecated metadata is used.
+func resourceInstanceCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() != "" && d.HasChange("vm_state") && d.NewValueKnown("vm_state") {
+		oldState, newState := d.GetChange("vm_state")
+		if _, err := instanceStateAction(oldState.(string), newState.(string)); err != nil {
+			return err
+		}
+	}
+
+	if d.Id() != "" && d.HasChange("volume") {
+		oldVolumes, newVolumes := d.GetChange("volume")
+		if !reflect.DeepEqual(createdVolumesHashes(oldVolumes.(*schema.Set).List()), createdVolumesHashes(newVolumes.(*schema.Set).List())) {
+			if err := d.ForceNew("volume"); err != nil {
+				return err
+			}
+		}
+	}
+
+	if _, ok := d.GetOk("metadata"); ok {
+		return nil
+	}
+
+	return customizeDiffMetadataAll(ctx, d, m)
+}
@@ -0,0 +1,344 @@
+package edgecenter
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/Edge-Center/edgecentercloud-go/edgecenter/instance/v1/instances"
+	"github.com/Edge-Center/edgecentercloud-go/edgecenter/instance/v1/types"
+	"github.com/Edge-Center/edgecentercloud-go/edgecenter/task/v1/tasks"
+)
+
+const instanceInterfaceTimeout int = 1200
+
+func resourceInstanceInterface() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceInstanceInterfaceCreate,
+		ReadContext:   resourceInstanceInterfaceRead,
+		UpdateContext: resourceInstanceInterfaceUpdate,
+		DeleteContext: resourceInstanceInterfaceDelete,
+		Description: `Attaches a network interface to an instance. Interfaces attached this way are ignored by the interface list of edgecenter_instance,
+so the network interfaces and the instance may be managed separately.`,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(time.Duration(instanceInterfaceTimeout) * time.Second),
+			Update: schema.DefaultTimeout(time.Duration(instanceInterfaceTimeout) * time.Second),
+			Delete: schema.DefaultTimeout(time.Duration(instanceInterfaceTimeout) * time.Second),
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				projectID, regionID, instanceID, portID, err := ImportStringParserExtended(meta.(*Config), d.Id())
+				if err != nil {
+					return nil, err
+				}
+				d.Set("project_id", projectID)
+				d.Set("region_id", regionID)
+				d.Set("instance_id", instanceID)
+				d.SetId(portID)
+
+				return []*schema.ResourceData{d}, nil
+			},
+		},
+
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:          schema.TypeInt,
+				Optional:      true,
+				Computed:      true,
+				ForceNew:      true,
+				Description:   "The ID of the project. Either 'project_id' or 'project_name' must be specified, unless the provider sets a default project.",
+				ConflictsWith: []string{"project_name"},
+			},
+			"project_name": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				Description:   "The name of the project. Either 'project_id' or 'project_name' must be specified, unless the provider sets a default project.",
+				ConflictsWith: []string{"project_id"},
+			},
+			"region_id": {
+				Type:          schema.TypeInt,
+				Optional:      true,
+				Computed:      true,
+				ForceNew:      true,
+				Description:   "The ID of the region. Either 'region_id' or 'region_name' must be specified, unless the provider sets a default region.",
+				ConflictsWith: []string{"region_name"},
+			},
+			"region_name": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				Description:   "The name of the region. Either 'region_id' or 'region_name' must be specified, unless the provider sets a default region.",
+				ConflictsWith: []string{"region_id"},
+			},
+			"instance_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The uuid of the instance to attach the interface to.",
+			},
+			"type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  fmt.Sprintf("The type of the interface. Available values are '%s', '%s', '%s' and '%s'.", types.SubnetInterfaceType, types.AnySubnetInterfaceType, types.ExternalInterfaceType, types.ReservedFixedIPType),
+				ValidateFunc: validation.StringInSlice(types.InterfaceType("").StringList(), false),
+			},
+			"network_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The uuid of the network. Required if type is 'any_subnet'.",
+			},
+			"subnet_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The uuid of the subnet. Required if type is 'subnet'.",
+			},
+			"port_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The uuid of the port of the reserved fixed IP. Required if type is 'reserved_fixed_ip'.",
+			},
+			"fip_source": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Description:  fmt.Sprintf("The source of a floating IP for the interface. Available values are '%s' and '%s'.", types.NewFloatingIP, types.ExistingFloatingIP),
+				ValidateFunc: validation.StringInSlice(types.FloatingIPSource("").StringList(), false),
+			},
+			"existing_fip_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Description:  "The uuid of the floating IP. Required if fip_source is 'existing'.",
+				RequiredWith: []string{"fip_source"},
+			},
+			"security_groups": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Computed:    true,
+				Description: "A list of security group IDs applied to the interface.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"ip_address": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The IP address of the interface.",
+			},
+			"floating_ip_address": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The floating IP address of the interface, if any.",
+			},
+			"mac_address": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The MAC address of the interface.",
+			},
+		},
+	}
+}
+
+func resourceInstanceInterfaceCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Println("[DEBUG] Start instance interface creating")
+	config := m.(*Config)
+
+	client, err := CreateClient(config, d, InstancePoint, VersionPointV1)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	interfaceOpts, err := decodeInstanceInterfaceOpts(map[string]interface{}{
+		"type":            d.Get("type").(string),
+		"network_id":      d.Get("network_id").(string),
+		"subnet_id":       d.Get("subnet_id").(string),
+		"port_id":         d.Get("port_id").(string),
+		"fip_source":      d.Get("fip_source").(string),
+		"existing_fip_id": d.Get("existing_fip_id").(string),
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	opts := instances.InterfaceInstanceCreateOpts{
+		InterfaceOpts:  interfaceOpts,
+		SecurityGroups: getSecurityGroupsIDs(d.Get("security_groups").([]interface{})),
+	}
+
+	instanceID := d.Get("instance_id").(string)
+	log.Printf("[DEBUG] Instance %s attach interface options: %+v", instanceID, opts)
+	results, err := instances.AttachInterface(client, instanceID, opts).Extract()
+	if err != nil {
+		return diag.Errorf("cannot attach interface %s to instance %s. Error: %s", opts.Type, instanceID, err)
+	}
+
+	taskID := results.Tasks[0]
+	log.Printf("[DEBUG] Task id (%s)", taskID)
+	portID, err := waitTaskAndReturnResult(ctx, client, taskID, d.Timeout(schema.TimeoutCreate), func(task tasks.TaskID) (interface{}, error) {
+		taskInfo, err := tasks.Get(client, string(task)).Extract()
+		if err != nil {
+			return nil, fmt.Errorf("cannot get task with ID: %s. Error: %w", task, err)
+		}
+		if opts.Type == types.ReservedFixedIPType {
+			return opts.PortID, nil
+		}
+		portID, err := instances.ExtractInstancePortIDFromTask(taskInfo)
+		if err != nil {
+			return nil, fmt.Errorf("cannot retrieve instance port ID from task info: %w", err)
+		}
+		return portID, nil
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(portID.(string))
+	log.Printf("[DEBUG] Finish instance interface creating (%s)", portID)
+
+	return resourceInstanceInterfaceRead(ctx, d, m)
+}
+
+func resourceInstanceInterfaceRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Println("[DEBUG] Start instance interface reading")
+	var diags diag.Diagnostics
+	config := m.(*Config)
+
+	client, err := CreateClient(config, d, InstancePoint, VersionPointV1)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	instanceID := d.Get("instance_id").(string)
+	interfaces, err := instances.ListInterfacesAll(client, instanceID)
+	if err != nil {
+		if removeIfNotFound(d, "instance interface", err) {
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	var iface *instances.Interface
+	for i := range interfaces {
+		if interfaces[i].PortID == d.Id() {
+			iface = &interfaces[i]
+			break
+		}
+	}
+	if iface == nil {
+		log.Printf("[WARN] Removing instance interface %s because it is not attached to the instance anymore", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	if d.Get("type").(string) == "" {
+		// import: the type isn't returned by the API, so it is derived from the network
+		if iface.NetworkDetails.External {
+			d.Set("type", types.ExternalInterfaceType.String())
+		} else {
+			d.Set("type", types.SubnetInterfaceType.String())
+		}
+	}
+	d.Set("network_id", iface.NetworkID)
+	d.Set("mac_address", iface.MacAddress.String())
+	if len(iface.IPAssignments) > 0 {
+		d.Set("subnet_id", iface.IPAssignments[0].SubnetID)
+		d.Set("ip_address", iface.IPAssignments[0].IPAddress.String())
+	}
+	if d.Get("type").(string) == types.ReservedFixedIPType.String() {
+		d.Set("port_id", iface.PortID)
+	}
+	if len(iface.FloatingIPDetails) > 0 {
+		d.Set("floating_ip_address", iface.FloatingIPDetails[0].FloatingIPAddress.String())
+	} else {
+		d.Set("floating_ip_address", "")
+	}
+
+	instancePorts, err := instances.ListPortsAll(client, instanceID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if port, err := findInstancePort(d.Id(), instancePorts); err == nil {
+		sgs := make([]string, len(port.SecurityGroups))
+		for i, sg := range port.SecurityGroups {
+			sgs[i] = sg.ID
+		}
+		d.Set("security_groups", sgs)
+	}
+
+	fields := []string{"project_id", "region_id"}
+	revertState(d, &fields)
+
+	log.Println("[DEBUG] Finish instance interface reading")
+
+	return diags
+}
+
+func resourceInstanceInterfaceUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Println("[DEBUG] Start instance interface updating")
+	config := m.(*Config)
+
+	if d.HasChange("security_groups") {
+		client, err := CreateClient(config, d, InstancePoint, VersionPointV1)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		sgClient, err := CreateClient(config, d, SecurityGroupPoint, VersionPointV1)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		instanceID := d.Get("instance_id").(string)
+		oldSGsRaw, newSGsRaw := d.GetChange("security_groups")
+		sgsIDsOld := getSecurityGroupsIDs(oldSGsRaw.([]interface{}))
+		sgsIDsNew := getSecurityGroupsIDs(newSGsRaw.([]interface{}))
+		removeSGs := getSecurityGroupsDifference(sgsIDsNew, sgsIDsOld)
+		if err := removeSecurityGroupFromInstance(sgClient, client, instanceID, d.Id(), removeSGs); err != nil {
+			return diag.FromErr(err)
+		}
+		addSGs := getSecurityGroupsDifference(sgsIDsOld, sgsIDsNew)
+		if err := attachSecurityGroupToInstance(sgClient, client, instanceID, d.Id(), addSGs); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	log.Println("[DEBUG] Finish instance interface updating")
+
+	return resourceInstanceInterfaceRead(ctx, d, m)
+}
+
+func resourceInstanceInterfaceDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Println("[DEBUG] Start instance interface deleting")
+	var diags diag.Diagnostics
+	config := m.(*Config)
+
+	client, err := CreateClient(config, d, InstancePoint, VersionPointV1)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	instanceID := d.Get("instance_id").(string)
+	iface := map[string]interface{}{
+		"port_id":    d.Id(),
+		"ip_address": d.Get("ip_address").(string),
+	}
+	if err := detachInterfaceFromInstance(ctx, client, instanceID, iface, d.Timeout(schema.TimeoutDelete)); err != nil {
+		if !isNotFound(err) {
+			return diag.Errorf("cannot detach interface %s from instance %s. Error: %s", d.Id(), instanceID, err)
+		}
+	}
+
+	d.SetId("")
+	log.Printf("[DEBUG] Finish of instance interface deleting")
+
+	return diags
+}
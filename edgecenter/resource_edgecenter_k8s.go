@@ -2,7 +2,6 @@ package edgecenter
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"log"
 	"time"
@@ -10,7 +9,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
-	edgecloud "github.com/Edge-Center/edgecentercloud-go"
 	"github.com/Edge-Center/edgecentercloud-go/edgecenter/k8s/v1/clusters"
 	"github.com/Edge-Center/edgecentercloud-go/edgecenter/k8s/v1/pools"
 	"github.com/Edge-Center/edgecentercloud-go/edgecenter/keypair/v2/keypairs"
@@ -35,10 +33,11 @@ func resourceK8s() *schema.Resource {
 		Timeouts: &schema.ResourceTimeout{
 			Create: &k8sCreateTimeout,
 			Update: &k8sCreateTimeout,
+			Delete: &k8sCreateTimeout,
 		},
 		Importer: &schema.ResourceImporter{
 			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-				projectID, regionID, k8sID, err := ImportStringParser(d.Id())
+				projectID, regionID, k8sID, err := ImportStringParser(meta.(*Config), d.Id())
 				if err != nil {
 					return nil, err
 				}
@@ -52,28 +51,30 @@ func resourceK8s() *schema.Resource {
 
 		Schema: map[string]*schema.Schema{
 			"project_id": {
-				Type:         schema.TypeInt,
-				Optional:     true,
-				Description:  "The uuid of the project. Either 'project_id' or 'project_name' must be specified.",
-				ExactlyOneOf: []string{"project_id", "project_name"},
+				Type:          schema.TypeInt,
+				Optional:      true,
+				Computed:      true,
+				Description:   "The ID of the project. Either 'project_id' or 'project_name' must be specified, unless the provider sets a default project.",
+				ConflictsWith: []string{"project_name"},
 			},
 			"project_name": {
-				Type:         schema.TypeString,
-				Optional:     true,
-				Description:  "The name of the project. Either 'project_id' or 'project_name' must be specified.",
-				ExactlyOneOf: []string{"project_id", "project_name"},
+				Type:          schema.TypeString,
+				Optional:      true,
+				Description:   "The name of the project. Either 'project_id' or 'project_name' must be specified, unless the provider sets a default project.",
+				ConflictsWith: []string{"project_id"},
 			},
 			"region_id": {
-				Type:         schema.TypeInt,
-				Optional:     true,
-				Description:  "The uuid of the region. Either 'region_id' or 'region_name' must be specified.",
-				ExactlyOneOf: []string{"region_id", "region_name"},
+				Type:          schema.TypeInt,
+				Optional:      true,
+				Computed:      true,
+				Description:   "The ID of the region. Either 'region_id' or 'region_name' must be specified, unless the provider sets a default region.",
+				ConflictsWith: []string{"region_name"},
 			},
 			"region_name": {
-				Type:         schema.TypeString,
-				Optional:     true,
-				Description:  "The name of the region. Either 'region_id' or 'region_name' must be specified.",
-				ExactlyOneOf: []string{"region_id", "region_name"},
+				Type:          schema.TypeString,
+				Optional:      true,
+				Description:   "The name of the region. Either 'region_id' or 'region_name' must be specified, unless the provider sets a default region.",
+				ConflictsWith: []string{"region_id"},
 			},
 			"name": {
 				Type:        schema.TypeString,
@@ -275,9 +276,8 @@ func resourceK8sCreate(ctx context.Context, d *schema.ResourceData, m interface{
 	log.Println("[DEBUG] Start K8s creating")
 	var diags diag.Diagnostics
 	config := m.(*Config)
-	provider := config.Provider
 
-	client, err := CreateClient(provider, d, K8sPoint, VersionPointV1)
+	client, err := CreateClient(config, d, K8sPoint, VersionPointV1)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -339,7 +339,7 @@ func resourceK8sCreate(ctx context.Context, d *schema.ResourceData, m interface{
 
 	taskID := results.Tasks[0]
 	log.Printf("[DEBUG] Task id (%s)", taskID)
-	k8sID, err := tasks.WaitTaskAndReturnResult(client, taskID, true, K8sCreateTimeout, func(task tasks.TaskID) (interface{}, error) {
+	k8sID, err := waitTaskAndReturnResult(ctx, client, taskID, d.Timeout(schema.TimeoutCreate), func(task tasks.TaskID) (interface{}, error) {
 		taskInfo, err := tasks.Get(client, string(task)).Extract()
 		if err != nil {
 			return nil, fmt.Errorf("cannot get task with ID: %s. Error: %w", task, err)
@@ -367,9 +367,8 @@ func resourceK8sRead(_ context.Context, d *schema.ResourceData, m interface{}) d
 	log.Println("[DEBUG] Start K8s reading")
 	var diags diag.Diagnostics
 	config := m.(*Config)
-	provider := config.Provider
 
-	clientK8S, err := CreateClient(provider, d, K8sPoint, VersionPointV1)
+	clientK8S, err := CreateClient(config, d, K8sPoint, VersionPointV1)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -377,6 +376,9 @@ func resourceK8sRead(_ context.Context, d *schema.ResourceData, m interface{}) d
 	clusterID := d.Id()
 	cluster, err := clusters.Get(clientK8S, clusterID).Extract()
 	if err != nil {
+		if removeIfNotFound(d, "k8s cluster", err) {
+			return nil
+		}
 		return diag.FromErr(err)
 	}
 
@@ -388,7 +390,7 @@ func resourceK8sRead(_ context.Context, d *schema.ResourceData, m interface{}) d
 	d.Set("status", cluster.Status)
 	d.Set("status_reason", cluster.StatusReason)
 
-	clientKeypairs, err := CreateClient(provider, d, KeypairsPoint, VersionPointV2)
+	clientKeypairs, err := CreateClient(config, d, KeypairsPoint, VersionPointV2)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -469,9 +471,8 @@ func resourceK8sRead(_ context.Context, d *schema.ResourceData, m interface{}) d
 func resourceK8sUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	log.Println("[DEBUG] Start K8s updating")
 	config := m.(*Config)
-	provider := config.Provider
 
-	client, err := CreateClient(provider, d, K8sPoint, VersionPointV1)
+	client, err := CreateClient(config, d, K8sPoint, VersionPointV1)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -495,7 +496,7 @@ func resourceK8sUpdate(ctx context.Context, d *schema.ResourceData, m interface{
 			}
 
 			taskID := results.Tasks[0]
-			_, err = tasks.WaitTaskAndReturnResult(client, taskID, true, K8sCreateTimeout, func(task tasks.TaskID) (interface{}, error) {
+			_, err = waitTaskAndReturnResult(ctx, client, taskID, d.Timeout(schema.TimeoutUpdate), func(task tasks.TaskID) (interface{}, error) {
 				_, err := pools.Get(client, clusterID, poolID).Extract()
 				if err != nil {
 					return nil, fmt.Errorf("cannot get pool with ID: %s. Error: %w", poolID, err)
@@ -517,7 +518,7 @@ func resourceK8sUpdate(ctx context.Context, d *schema.ResourceData, m interface{
 			}
 
 			taskID := results.Tasks[0]
-			_, err = tasks.WaitTaskAndReturnResult(client, taskID, true, K8sCreateTimeout, func(task tasks.TaskID) (interface{}, error) {
+			_, err = waitTaskAndReturnResult(ctx, client, taskID, d.Timeout(schema.TimeoutUpdate), func(task tasks.TaskID) (interface{}, error) {
 				_, err := pools.Get(client, clusterID, poolID).Extract()
 				if err != nil {
 					return nil, fmt.Errorf("cannot get pool with ID: %s. Error: %w", poolID, err)
@@ -533,13 +534,12 @@ func resourceK8sUpdate(ctx context.Context, d *schema.ResourceData, m interface{
 	return resourceK8sRead(ctx, d, m)
 }
 
-func resourceK8sDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+func resourceK8sDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	log.Println("[DEBUG] Start K8s deleting")
 	var diags diag.Diagnostics
 	config := m.(*Config)
-	provider := config.Provider
 
-	client, err := CreateClient(provider, d, K8sPoint, VersionPointV1)
+	client, err := CreateClient(config, d, K8sPoint, VersionPointV1)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -551,13 +551,12 @@ func resourceK8sDelete(_ context.Context, d *schema.ResourceData, m interface{})
 	}
 
 	taskID := results.Tasks[0]
-	_, err = tasks.WaitTaskAndReturnResult(client, taskID, true, K8sCreateTimeout, func(task tasks.TaskID) (interface{}, error) {
+	_, err = waitTaskAndReturnResult(ctx, client, taskID, d.Timeout(schema.TimeoutDelete), func(task tasks.TaskID) (interface{}, error) {
 		_, err := clusters.Get(client, id).Extract()
 		if err == nil {
 			return nil, fmt.Errorf("cannot delete k8s cluster with ID: %s", id)
 		}
-		var errDefault404 edgecloud.Default404Error
-		if errors.As(err, &errDefault404) {
+		if isNotFound(err) {
 			return nil, nil
 		}
 		return nil, fmt.Errorf("extracting Cluster resource error: %w", err)
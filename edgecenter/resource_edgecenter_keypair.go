@@ -2,42 +2,114 @@ package edgecenter
 
 import (
 	"context"
+	"fmt"
 	"log"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
+	edgecloud "github.com/Edge-Center/edgecentercloud-go"
 	"github.com/Edge-Center/edgecentercloud-go/edgecenter/keypair/v2/keypairs"
 )
 
 const KeypairsPoint = "keypairs"
 
+// keypairInfo is a keypair with the fields of the API response the SDK doesn't decode.
+type keypairInfo struct {
+	keypairs.KeyPair
+	SharedInProject bool `json:"shared_in_project"`
+}
+
+// keypairCreateOpts extends keypairs.CreateOpts with sharing, and leaves out the public key
+// to have the platform generate it.
+type keypairCreateOpts struct {
+	Name            string `json:"sshkey_name" required:"true"`
+	PublicKey       string `json:"public_key,omitempty"`
+	ProjectID       int    `json:"project_id" required:"true"`
+	SharedInProject bool   `json:"shared_in_project"`
+}
+
+// ToKeyPairCreateMap builds a request body.
+func (opts keypairCreateOpts) ToKeyPairCreateMap() (map[string]interface{}, error) {
+	return edgecloud.BuildRequestBody(opts, "")
+}
+
 func resourceKeypair() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceKeypairCreate,
 		ReadContext:   resourceKeypairRead,
 		DeleteContext: resourceKeypairDelete,
-		Description:   "Represent a ssh key, do not depends on region",
+		CustomizeDiff: resourceKeypairCustomizeDiff,
+		Description: `Represent a ssh key, do not depends on region.
+The key is either uploaded from 'public_key' or generated with 'generate', in which case 'private_key' holds its private part.`,
+		Importer: &schema.ResourceImporter{
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				config := meta.(*Config)
+				parts := strings.Split(d.Id(), ":")
+				if len(parts) != 2 {
+					return nil, fmt.Errorf("invalid import ID %q, expected <project>:<sshkey_id>", d.Id())
+				}
+				projectID, err := parseImportSegment("project", parts[0], config.resolveProject)
+				if err != nil {
+					return nil, err
+				}
+				d.Set("project_id", projectID)
+				d.SetId(parts[1])
+
+				return []*schema.ResourceData{d}, nil
+			},
+		},
 		Schema: map[string]*schema.Schema{
 			"project_id": {
-				Type:         schema.TypeInt,
-				Optional:     true,
-				ForceNew:     true,
-				Description:  "The uuid of the project. Either 'project_id' or 'project_name' must be specified.",
-				ExactlyOneOf: []string{"project_id", "project_name"},
+				Type:          schema.TypeInt,
+				Optional:      true,
+				Computed:      true,
+				ForceNew:      true,
+				Description:   "The ID of the project. Either 'project_id' or 'project_name' must be specified, unless the provider sets a default project.",
+				ConflictsWith: []string{"project_name"},
 			},
 			"project_name": {
-				Type:         schema.TypeString,
-				Optional:     true,
-				ForceNew:     true,
-				Description:  "The name of the project. Either 'project_id' or 'project_name' must be specified.",
-				ExactlyOneOf: []string{"project_id", "project_name"},
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				Description:   "The name of the project. Either 'project_id' or 'project_name' must be specified, unless the provider sets a default project.",
+				ConflictsWith: []string{"project_id"},
 			},
 			"public_key": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The public portion of the SSH key pair. Required unless 'generate' is true, which conflicts with it.",
+			},
+			"generate": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Set to true to generate the key pair instead of uploading 'public_key'. The private part is stored in 'private_key'.",
+			},
+			"algorithm": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Description: fmt.Sprintf(`The algorithm of a generated key, '%s' or '%s', to have the provider generate it.
+By default the platform generates the key. Only allowed when 'generate' is true.`, KeypairAlgorithmED25519, KeypairAlgorithmRSA),
+				ValidateFunc: validation.StringInSlice([]string{KeypairAlgorithmED25519, KeypairAlgorithmRSA}, false),
+			},
+			"private_key": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The private part of a generated key pair in PEM. It is only known to the state which created the key pair.",
+			},
+			"shared_in_project": {
+				Type:        schema.TypeBool,
+				Optional:    true,
 				ForceNew:    true,
-				Description: "The public portion of the SSH key pair.",
+				Default:     false,
+				Description: "Set to true to share the key pair with all users of the project. The key pair is recreated when it changes.",
 			},
 			"sshkey_name": {
 				Type:        schema.TypeString,
@@ -64,23 +136,35 @@ func resourceKeypairCreate(ctx context.Context, d *schema.ResourceData, m interf
 
 	var diags diag.Diagnostics
 	config := m.(*Config)
-	provider := config.Provider
 
-	client, err := CreateClient(provider, d, KeypairsPoint, VersionPointV2)
+	client, err := CreateClient(config, d, KeypairsPoint, VersionPointV2)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	opts := keypairs.CreateOpts{
-		Name:      d.Get("sshkey_name").(string),
-		PublicKey: d.Get("public_key").(string),
-		ProjectID: d.Get("project_id").(int),
+	opts := keypairCreateOpts{
+		Name:            d.Get("sshkey_name").(string),
+		PublicKey:       d.Get("public_key").(string),
+		ProjectID:       d.Get("project_id").(int),
+		SharedInProject: d.Get("shared_in_project").(bool),
+	}
+
+	var privateKey string
+	if algorithm := d.Get("algorithm").(string); d.Get("generate").(bool) && algorithm != "" {
+		opts.PublicKey, privateKey, err = generateKeyPair(algorithm)
+		if err != nil {
+			return diag.FromErr(err)
+		}
 	}
 
 	kp, err := keypairs.Create(client, opts).Extract()
 	if err != nil {
 		return diag.FromErr(err)
 	}
+	if kp.PrivateKey != nil {
+		privateKey = *kp.PrivateKey
+	}
+	d.Set("private_key", privateKey)
 
 	log.Printf("[DEBUG] KeyPair id (%s)", kp.ID)
 	d.SetId(kp.ID)
@@ -97,16 +181,19 @@ func resourceKeypairRead(_ context.Context, d *schema.ResourceData, m interface{
 
 	var diags diag.Diagnostics
 	config := m.(*Config)
-	provider := config.Provider
 
-	client, err := CreateClient(provider, d, KeypairsPoint, VersionPointV2)
+	client, err := CreateClient(config, d, KeypairsPoint, VersionPointV2)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
 	kpID := d.Id()
-	kp, err := keypairs.Get(client, kpID).Extract()
+	var kp keypairInfo
+	err = keypairs.Get(client, kpID).ExtractInto(&kp)
 	if err != nil {
+		if removeIfNotFound(d, "keypair", err) {
+			return nil
+		}
 		return diag.Errorf("cannot get keypairs with ID %s. Error: %s", kpID, err.Error())
 	}
 
@@ -115,20 +202,49 @@ func resourceKeypairRead(_ context.Context, d *schema.ResourceData, m interface{
 	d.Set("sshkey_id", kp.ID)
 	d.Set("fingerprint", kp.Fingerprint)
 	d.Set("project_id", kp.ProjectID)
+	d.Set("shared_in_project", kp.SharedInProject)
 
 	log.Println("[DEBUG] Finish KeyPair reading")
 
 	return diags
 }
 
+// resourceKeypairCustomizeDiff checks the key is either uploaded from public_key or generated,
+// as generate = false takes neither algorithm nor an empty public_key.
+func resourceKeypairCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if !d.NewValueKnown("generate") {
+		return nil
+	}
+
+	rawConfig := d.GetRawConfig()
+	if rawConfig.IsNull() {
+		return nil
+	}
+	hasPublicKey := !rawConfig.GetAttr("public_key").IsNull()
+
+	if d.Get("generate").(bool) {
+		if hasPublicKey {
+			return fmt.Errorf("public_key can't be set when generate is true")
+		}
+		return nil
+	}
+	if !rawConfig.GetAttr("algorithm").IsNull() {
+		return fmt.Errorf("algorithm can only be set when generate is true")
+	}
+	if !hasPublicKey {
+		return fmt.Errorf("public_key must be set unless generate is true")
+	}
+
+	return nil
+}
+
 func resourceKeypairDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	log.Println("[DEBUG] Start KeyPair deleting")
 
 	var diags diag.Diagnostics
 	config := m.(*Config)
-	provider := config.Provider
 
-	client, err := CreateClient(provider, d, KeypairsPoint, VersionPointV2)
+	client, err := CreateClient(config, d, KeypairsPoint, VersionPointV2)
 	if err != nil {
 		return diag.FromErr(err)
 	}
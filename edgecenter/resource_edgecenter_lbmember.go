@@ -2,7 +2,6 @@ package edgecenter
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"log"
 	"net"
@@ -12,7 +11,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
-	edgecloud "github.com/Edge-Center/edgecentercloud-go"
 	"github.com/Edge-Center/edgecentercloud-go/edgecenter/loadbalancer/v1/lbpools"
 	"github.com/Edge-Center/edgecentercloud-go/edgecenter/task/v1/tasks"
 )
@@ -30,12 +28,13 @@ func resourceLBMember() *schema.Resource {
 		DeleteContext: resourceLBMemberDelete,
 		Description:   "Represent load balancer member",
 		Timeouts: &schema.ResourceTimeout{
-			Create: schema.DefaultTimeout(5 * time.Minute),
-			Delete: schema.DefaultTimeout(5 * time.Minute),
+			Create: schema.DefaultTimeout(time.Duration(LBPoolsCreateTimeout) * time.Second),
+			Update: schema.DefaultTimeout(time.Duration(LBPoolsCreateTimeout) * time.Second),
+			Delete: schema.DefaultTimeout(time.Duration(LBPoolsCreateTimeout) * time.Second),
 		},
 		Importer: &schema.ResourceImporter{
 			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-				projectID, regionID, memberID, lbPoolID, err := ImportStringParserExtended(d.Id())
+				projectID, regionID, memberID, lbPoolID, err := ImportStringParserExtended(meta.(*Config), d.Id())
 				if err != nil {
 					return nil, err
 				}
@@ -50,32 +49,34 @@ func resourceLBMember() *schema.Resource {
 
 		Schema: map[string]*schema.Schema{
 			"project_id": {
-				Type:         schema.TypeInt,
-				Optional:     true,
-				ForceNew:     true,
-				Description:  "The uuid of the project. Either 'project_id' or 'project_name' must be specified.",
-				ExactlyOneOf: []string{"project_id", "project_name"},
+				Type:          schema.TypeInt,
+				Optional:      true,
+				Computed:      true,
+				ForceNew:      true,
+				Description:   "The ID of the project. Either 'project_id' or 'project_name' must be specified, unless the provider sets a default project.",
+				ConflictsWith: []string{"project_name"},
 			},
 			"project_name": {
-				Type:         schema.TypeString,
-				Optional:     true,
-				ForceNew:     true,
-				Description:  "The name of the project. Either 'project_id' or 'project_name' must be specified.",
-				ExactlyOneOf: []string{"project_id", "project_name"},
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				Description:   "The name of the project. Either 'project_id' or 'project_name' must be specified, unless the provider sets a default project.",
+				ConflictsWith: []string{"project_id"},
 			},
 			"region_id": {
-				Type:         schema.TypeInt,
-				Optional:     true,
-				ForceNew:     true,
-				Description:  "The uuid of the region. Either 'region_id' or 'region_name' must be specified.",
-				ExactlyOneOf: []string{"region_id", "region_name"},
+				Type:          schema.TypeInt,
+				Optional:      true,
+				Computed:      true,
+				ForceNew:      true,
+				Description:   "The ID of the region. Either 'region_id' or 'region_name' must be specified, unless the provider sets a default region.",
+				ConflictsWith: []string{"region_name"},
 			},
 			"region_name": {
-				Type:         schema.TypeString,
-				Optional:     true,
-				ForceNew:     true,
-				Description:  "The name of the region. Either 'region_id' or 'region_name' must be specified.",
-				ExactlyOneOf: []string{"region_id", "region_name"},
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				Description:   "The name of the region. Either 'region_id' or 'region_name' must be specified, unless the provider sets a default region.",
+				ConflictsWith: []string{"region_id"},
 			},
 			"pool_id": {
 				Type:        schema.TypeString,
@@ -144,9 +145,8 @@ func resourceLBMemberCreate(ctx context.Context, d *schema.ResourceData, m inter
 	log.Println("[DEBUG] Start LBMember creating")
 	var diags diag.Diagnostics
 	config := m.(*Config)
-	provider := config.Provider
 
-	client, err := CreateClient(provider, d, LBPoolsPoint, VersionPointV1)
+	client, err := CreateClient(config, d, LBPoolsPoint, VersionPointV1)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -165,7 +165,7 @@ func resourceLBMemberCreate(ctx context.Context, d *schema.ResourceData, m inter
 	}
 
 	taskID := results.Tasks[0]
-	pmID, err := tasks.WaitTaskAndReturnResult(client, taskID, true, LBPoolsCreateTimeout, func(task tasks.TaskID) (interface{}, error) {
+	pmID, err := waitTaskAndReturnResult(ctx, client, taskID, d.Timeout(schema.TimeoutCreate), func(task tasks.TaskID) (interface{}, error) {
 		taskInfo, err := tasks.Get(client, string(task)).Extract()
 		if err != nil {
 			return nil, fmt.Errorf("cannot get task with ID: %s. Error: %w", task, err)
@@ -192,21 +192,25 @@ func resourceLBMemberRead(_ context.Context, d *schema.ResourceData, m interface
 	log.Println("[DEBUG] Start LBMember reading")
 	var diags diag.Diagnostics
 	config := m.(*Config)
-	provider := config.Provider
 
-	client, err := CreateClient(provider, d, LBPoolsPoint, VersionPointV1)
+	client, err := CreateClient(config, d, LBPoolsPoint, VersionPointV1)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
 	pool, err := lbpools.Get(client, d.Get("pool_id").(string)).Extract()
 	if err != nil {
+		if removeIfNotFound(d, "lbmember", err) {
+			return nil
+		}
 		return diag.FromErr(err)
 	}
 
 	mid := d.Id()
+	var found bool
 	for _, pm := range pool.Members {
 		if mid == pm.ID {
+			found = true
 			d.Set("address", pm.Address.String())
 			d.Set("protocol_port", pm.ProtocolPort)
 			d.Set("weight", pm.Weight)
@@ -215,6 +219,11 @@ func resourceLBMemberRead(_ context.Context, d *schema.ResourceData, m interface
 			d.Set("operating_status", pm.OperatingStatus)
 		}
 	}
+	if !found {
+		log.Printf("[WARN] Removing lbmember %s because resource doesn't exist anymore", mid)
+		d.SetId("")
+		return nil
+	}
 
 	fields := []string{"project_id", "region_id"}
 	revertState(d, &fields)
@@ -227,9 +236,8 @@ func resourceLBMemberRead(_ context.Context, d *schema.ResourceData, m interface
 func resourceLBMemberUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	log.Println("[DEBUG] Start LBMember updating")
 	config := m.(*Config)
-	provider := config.Provider
 
-	client, err := CreateClient(provider, d, LBPoolsPoint, VersionPointV1)
+	client, err := CreateClient(config, d, LBPoolsPoint, VersionPointV1)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -270,7 +278,7 @@ func resourceLBMemberUpdate(ctx context.Context, d *schema.ResourceData, m inter
 	}
 
 	taskID := results.Tasks[0]
-	_, err = tasks.WaitTaskAndReturnResult(client, taskID, true, LBPoolsCreateTimeout, func(task tasks.TaskID) (interface{}, error) {
+	_, err = waitTaskAndReturnResult(ctx, client, taskID, d.Timeout(schema.TimeoutUpdate), func(task tasks.TaskID) (interface{}, error) {
 		taskInfo, err := tasks.Get(client, string(task)).Extract()
 		if err != nil {
 			return nil, fmt.Errorf("cannot get task with ID: %s. Error: %w", task, err)
@@ -291,13 +299,12 @@ func resourceLBMemberUpdate(ctx context.Context, d *schema.ResourceData, m inter
 	return resourceLBMemberRead(ctx, d, m)
 }
 
-func resourceLBMemberDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+func resourceLBMemberDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	log.Println("[DEBUG] Start LBMember deleting")
 	var diags diag.Diagnostics
 	config := m.(*Config)
-	provider := config.Provider
 
-	client, err := CreateClient(provider, d, LBPoolsPoint, VersionPointV1)
+	client, err := CreateClient(config, d, LBPoolsPoint, VersionPointV1)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -306,8 +313,7 @@ func resourceLBMemberDelete(_ context.Context, d *schema.ResourceData, m interfa
 	pid := d.Get("pool_id").(string)
 	results, err := lbpools.DeleteMember(client, pid, mid).Extract()
 	if err != nil {
-		var errDefault404 edgecloud.Default404Error
-		if errors.As(err, &errDefault404) {
+		if isNotFound(err) {
 			d.SetId("")
 			log.Printf("[DEBUG] Finish of LBMember deleting")
 			return diags
@@ -316,7 +322,7 @@ func resourceLBMemberDelete(_ context.Context, d *schema.ResourceData, m interfa
 	}
 
 	taskID := results.Tasks[0]
-	_, err = tasks.WaitTaskAndReturnResult(client, taskID, true, LBPoolsCreateTimeout, func(task tasks.TaskID) (interface{}, error) {
+	_, err = waitTaskAndReturnResult(ctx, client, taskID, d.Timeout(schema.TimeoutDelete), func(task tasks.TaskID) (interface{}, error) {
 		pool, err := lbpools.Get(client, pid).Extract()
 		if err != nil {
 			return nil, fmt.Errorf("extracting LBPool resource error: %w", err)
@@ -32,7 +32,7 @@ func resourceLifecyclePolicy() *schema.Resource {
 		Description:   "Represent lifecycle policy. Use to periodically take snapshots",
 		Importer: &schema.ResourceImporter{
 			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-				projectID, regionID, lcpID, err := ImportStringParser(d.Id())
+				projectID, regionID, lcpID, err := ImportStringParser(meta.(*Config), d.Id())
 				if err != nil {
 					return nil, err
 				}
@@ -46,32 +46,34 @@ func resourceLifecyclePolicy() *schema.Resource {
 
 		Schema: map[string]*schema.Schema{
 			"project_id": {
-				Type:         schema.TypeInt,
-				Optional:     true,
-				ForceNew:     true,
-				Description:  "The uuid of the project. Either 'project_id' or 'project_name' must be specified.",
-				ExactlyOneOf: []string{"project_id", "project_name"},
+				Type:          schema.TypeInt,
+				Optional:      true,
+				Computed:      true,
+				ForceNew:      true,
+				Description:   "The ID of the project. Either 'project_id' or 'project_name' must be specified, unless the provider sets a default project.",
+				ConflictsWith: []string{"project_name"},
 			},
 			"project_name": {
-				Type:         schema.TypeString,
-				Optional:     true,
-				ForceNew:     true,
-				Description:  "The name of the project. Either 'project_id' or 'project_name' must be specified.",
-				ExactlyOneOf: []string{"project_id", "project_name"},
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				Description:   "The name of the project. Either 'project_id' or 'project_name' must be specified, unless the provider sets a default project.",
+				ConflictsWith: []string{"project_id"},
 			},
 			"region_id": {
-				Type:         schema.TypeInt,
-				Optional:     true,
-				ForceNew:     true,
-				Description:  "The uuid of the region. Either 'region_id' or 'region_name' must be specified.",
-				ExactlyOneOf: []string{"region_id", "region_name"},
+				Type:          schema.TypeInt,
+				Optional:      true,
+				Computed:      true,
+				ForceNew:      true,
+				Description:   "The ID of the region. Either 'region_id' or 'region_name' must be specified, unless the provider sets a default region.",
+				ConflictsWith: []string{"region_name"},
 			},
 			"region_name": {
-				Type:         schema.TypeString,
-				Optional:     true,
-				ForceNew:     true,
-				Description:  "The name of the region. Either 'region_id' or 'region_name' must be specified.",
-				ExactlyOneOf: []string{"region_id", "region_name"},
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				Description:   "The name of the region. Either 'region_id' or 'region_name' must be specified, unless the provider sets a default region.",
+				ConflictsWith: []string{"region_id"},
 			},
 			"name": {
 				Type:         schema.TypeString,
@@ -269,7 +271,7 @@ func resourceLifecyclePolicy() *schema.Resource {
 }
 
 func resourceLifecyclePolicyCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client, err := CreateClient(m.(*Config).Provider, d, LifecyclePolicyPoint, VersionPointV1)
+	client, err := CreateClient(m.(*Config), d, LifecyclePolicyPoint, VersionPointV1)
 	if err != nil {
 		return diag.Errorf("Error creating client: %s", err)
 	}
@@ -290,7 +292,7 @@ func resourceLifecyclePolicyCreate(ctx context.Context, d *schema.ResourceData,
 }
 
 func resourceLifecyclePolicyRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client, err := CreateClient(m.(*Config).Provider, d, LifecyclePolicyPoint, VersionPointV1)
+	client, err := CreateClient(m.(*Config), d, LifecyclePolicyPoint, VersionPointV1)
 	if err != nil {
 		return diag.Errorf("Error creating client: %s", err)
 	}
@@ -303,6 +305,9 @@ func resourceLifecyclePolicyRead(_ context.Context, d *schema.ResourceData, m in
 	log.Printf("[DEBUG] Start of LifecyclePolicy %s reading", id)
 	policy, err := lifecyclepolicy.Get(client, integerID, lifecyclepolicy.GetOpts{NeedVolumes: true}).Extract()
 	if err != nil {
+		if removeIfNotFound(d, "lifecycle policy", err) {
+			return nil
+		}
 		return diag.Errorf("Error getting lifecycle policy: %s", err)
 	}
 
@@ -323,7 +328,7 @@ func resourceLifecyclePolicyRead(_ context.Context, d *schema.ResourceData, m in
 }
 
 func resourceLifecyclePolicyUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client, err := CreateClient(m.(*Config).Provider, d, LifecyclePolicyPoint, VersionPointV1)
+	client, err := CreateClient(m.(*Config), d, LifecyclePolicyPoint, VersionPointV1)
 	if err != nil {
 		return diag.Errorf("Error creating client: %s", err)
 	}
@@ -357,7 +362,7 @@ func resourceLifecyclePolicyUpdate(ctx context.Context, d *schema.ResourceData,
 }
 
 func resourceLifecyclePolicyDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client, err := CreateClient(m.(*Config).Provider, d, LifecyclePolicyPoint, VersionPointV1)
+	client, err := CreateClient(m.(*Config), d, LifecyclePolicyPoint, VersionPointV1)
 	if err != nil {
 		return diag.Errorf("Error creating client: %s", err)
 	}
@@ -21,14 +21,15 @@ func resourceLoadBalancerV2() *schema.Resource {
 		ReadContext:   resourceLoadBalancerV2Read,
 		UpdateContext: resourceLoadBalancerV2Update,
 		DeleteContext: resourceLoadBalancerDelete,
+		CustomizeDiff: customizeDiffMetadataAll,
 		Description:   "Represent load balancer without nested listener",
 		Timeouts: &schema.ResourceTimeout{
-			Create: schema.DefaultTimeout(5 * time.Minute),
-			Delete: schema.DefaultTimeout(5 * time.Minute),
+			Create: schema.DefaultTimeout(time.Duration(LoadBalancerCreateTimeout) * time.Second),
+			Delete: schema.DefaultTimeout(time.Duration(LoadBalancerCreateTimeout) * time.Second),
 		},
 		Importer: &schema.ResourceImporter{
 			StateContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
-				projectID, regionID, lbID, err := ImportStringParser(d.Id())
+				projectID, regionID, lbID, err := ImportStringParser(m.(*Config), d.Id())
 				if err != nil {
 					return nil, err
 				}
@@ -42,32 +43,34 @@ func resourceLoadBalancerV2() *schema.Resource {
 
 		Schema: map[string]*schema.Schema{
 			"project_id": {
-				Type:         schema.TypeInt,
-				Optional:     true,
-				ForceNew:     true,
-				Description:  "The uuid of the project. Either 'project_id' or 'project_name' must be specified.",
-				ExactlyOneOf: []string{"project_id", "project_name"},
+				Type:          schema.TypeInt,
+				Optional:      true,
+				Computed:      true,
+				ForceNew:      true,
+				Description:   "The ID of the project. Either 'project_id' or 'project_name' must be specified, unless the provider sets a default project.",
+				ConflictsWith: []string{"project_name"},
 			},
 			"project_name": {
-				Type:         schema.TypeString,
-				Optional:     true,
-				ForceNew:     true,
-				Description:  "The name of the project. Either 'project_id' or 'project_name' must be specified.",
-				ExactlyOneOf: []string{"project_id", "project_name"},
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				Description:   "The name of the project. Either 'project_id' or 'project_name' must be specified, unless the provider sets a default project.",
+				ConflictsWith: []string{"project_id"},
 			},
 			"region_id": {
-				Type:         schema.TypeInt,
-				Optional:     true,
-				ForceNew:     true,
-				Description:  "The uuid of the region. Either 'region_id' or 'region_name' must be specified.",
-				ExactlyOneOf: []string{"region_id", "region_name"},
+				Type:          schema.TypeInt,
+				Optional:      true,
+				Computed:      true,
+				ForceNew:      true,
+				Description:   "The ID of the region. Either 'region_id' or 'region_name' must be specified, unless the provider sets a default region.",
+				ConflictsWith: []string{"region_name"},
 			},
 			"region_name": {
-				Type:         schema.TypeString,
-				Optional:     true,
-				ForceNew:     true,
-				Description:  "The name of the region. Either 'region_id' or 'region_name' must be specified.",
-				ExactlyOneOf: []string{"region_id", "region_name"},
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				Description:   "The name of the region. Either 'region_id' or 'region_name' must be specified, unless the provider sets a default region.",
+				ConflictsWith: []string{"region_id"},
 			},
 			"name": {
 				Type:        schema.TypeString,
@@ -120,6 +123,14 @@ func resourceLoadBalancerV2() *schema.Resource {
 					Type: schema.TypeString,
 				},
 			},
+			"metadata_all": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Description: "A map containing all metadata of the resource, including the provider `default_metadata`.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
 			"metadata_read_only": {
 				Type:        schema.TypeList,
 				Computed:    true,
@@ -149,9 +160,8 @@ func resourceLoadBalancerV2Create(ctx context.Context, d *schema.ResourceData, m
 	log.Println("[DEBUG] Start LoadBalancer creating")
 	var diags diag.Diagnostics
 	config := m.(*Config)
-	provider := config.Provider
 
-	client, err := CreateClient(provider, d, LoadBalancersPoint, VersionPointV1)
+	client, err := CreateClient(config, d, LoadBalancersPoint, VersionPointV1)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -163,7 +173,7 @@ func resourceLoadBalancerV2Create(ctx context.Context, d *schema.ResourceData, m
 		VipSubnetID:  d.Get("vip_subnet_id").(string),
 	}
 
-	if metadataRaw, ok := d.GetOk("metadata_map"); ok {
+	if metadataRaw := metadataWithDefaults(config, d.Get("metadata_map")); len(metadataRaw) > 0 {
 		meta, err := utils.MapInterfaceToMapString(metadataRaw)
 		if err != nil {
 			return diag.FromErr(err)
@@ -182,7 +192,7 @@ func resourceLoadBalancerV2Create(ctx context.Context, d *schema.ResourceData, m
 	}
 
 	taskID := results.Tasks[0]
-	lbID, err := tasks.WaitTaskAndReturnResult(client, taskID, true, LoadBalancerCreateTimeout, func(task tasks.TaskID) (interface{}, error) {
+	lbID, err := waitTaskAndReturnResult(ctx, client, taskID, d.Timeout(schema.TimeoutCreate), func(task tasks.TaskID) (interface{}, error) {
 		taskInfo, err := tasks.Get(client, string(task)).Extract()
 		if err != nil {
 			return nil, fmt.Errorf("cannot get task with ID: %s. Error: %w", task, err)
@@ -210,15 +220,17 @@ func resourceLoadBalancerV2Read(_ context.Context, d *schema.ResourceData, m int
 	log.Println("[DEBUG] Start LoadBalancer reading")
 	var diags diag.Diagnostics
 	config := m.(*Config)
-	provider := config.Provider
 
-	client, err := CreateClient(provider, d, LoadBalancersPoint, VersionPointV1)
+	client, err := CreateClient(config, d, LoadBalancersPoint, VersionPointV1)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
 	lb, err := loadbalancers.Get(client, d.Id()).Extract()
 	if err != nil {
+		if removeIfNotFound(d, "loadbalancer", err) {
+			return nil
+		}
 		return diag.FromErr(err)
 	}
 	d.Set("project_id", lb.ProjectID)
@@ -239,7 +251,10 @@ func resourceLoadBalancerV2Read(_ context.Context, d *schema.ResourceData, m int
 	}
 	metadataMap, metadataReadOnly := PrepareMetadata(metadataList)
 
-	if err = d.Set("metadata_map", metadataMap); err != nil {
+	if err = d.Set("metadata_map", metadataWithoutDefaults(config, metadataMap, d.Get("metadata_map"))); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("metadata_all", metadataMap); err != nil {
 		return diag.FromErr(err)
 	}
 
@@ -255,9 +270,8 @@ func resourceLoadBalancerV2Read(_ context.Context, d *schema.ResourceData, m int
 func resourceLoadBalancerV2Update(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	log.Println("[DEBUG] Start LoadBalancer updating")
 	config := m.(*Config)
-	provider := config.Provider
 
-	client, err := CreateClient(provider, d, LoadBalancersPoint, VersionPointV1)
+	client, err := CreateClient(config, d, LoadBalancersPoint, VersionPointV1)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -274,10 +288,10 @@ func resourceLoadBalancerV2Update(ctx context.Context, d *schema.ResourceData, m
 		d.Set("last_updated", time.Now().Format(time.RFC850))
 	}
 
-	if d.HasChange("metadata_map") {
-		_, nmd := d.GetChange("metadata_map")
+	if d.HasChanges("metadata_map", "metadata_all") {
+		nmd := metadataWithDefaults(config, d.Get("metadata_map"))
 
-		meta, err := utils.MapInterfaceToMapString(nmd.(map[string]interface{}))
+		meta, err := utils.MapInterfaceToMapString(nmd)
 		if err != nil {
 			return diag.Errorf("cannot get metadata. Error: %s", err)
 		}
@@ -2,7 +2,6 @@ package edgecenter
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"log"
 	"time"
@@ -10,7 +9,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
-	edgecloud "github.com/Edge-Center/edgecentercloud-go"
 	"github.com/Edge-Center/edgecentercloud-go/edgecenter/network/v1/networks"
 	"github.com/Edge-Center/edgecentercloud-go/edgecenter/task/v1/tasks"
 	"github.com/Edge-Center/edgecentercloud-go/edgecenter/utils"
@@ -30,10 +28,15 @@ func resourceNetwork() *schema.Resource {
 		ReadContext:   resourceNetworkRead,
 		UpdateContext: resourceNetworkUpdate,
 		DeleteContext: resourceNetworkDelete,
+		CustomizeDiff: customizeDiffMetadataAll,
 		Description:   "Represent network. A network is a software-defined network in a cloud computing infrastructure",
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(time.Duration(NetworkCreatingTimeout) * time.Second),
+			Delete: schema.DefaultTimeout(time.Duration(NetworkDeleting) * time.Second),
+		},
 		Importer: &schema.ResourceImporter{
 			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-				projectID, regionID, NetworkID, err := ImportStringParser(d.Id())
+				projectID, regionID, NetworkID, err := ImportStringParser(meta.(*Config), d.Id())
 				if err != nil {
 					return nil, err
 				}
@@ -47,28 +50,30 @@ func resourceNetwork() *schema.Resource {
 
 		Schema: map[string]*schema.Schema{
 			"project_id": {
-				Type:         schema.TypeInt,
-				Optional:     true,
-				Description:  "The uuid of the project. Either 'project_id' or 'project_name' must be specified.",
-				ExactlyOneOf: []string{"project_id", "project_name"},
+				Type:          schema.TypeInt,
+				Optional:      true,
+				Computed:      true,
+				Description:   "The ID of the project. Either 'project_id' or 'project_name' must be specified, unless the provider sets a default project.",
+				ConflictsWith: []string{"project_name"},
 			},
 			"project_name": {
-				Type:         schema.TypeString,
-				Optional:     true,
-				Description:  "The name of the project. Either 'project_id' or 'project_name' must be specified.",
-				ExactlyOneOf: []string{"project_id", "project_name"},
+				Type:          schema.TypeString,
+				Optional:      true,
+				Description:   "The name of the project. Either 'project_id' or 'project_name' must be specified, unless the provider sets a default project.",
+				ConflictsWith: []string{"project_id"},
 			},
 			"region_id": {
-				Type:         schema.TypeInt,
-				Optional:     true,
-				Description:  "The uuid of the region. Either 'region_id' or 'region_name' must be specified.",
-				ExactlyOneOf: []string{"region_id", "region_name"},
+				Type:          schema.TypeInt,
+				Optional:      true,
+				Computed:      true,
+				Description:   "The ID of the region. Either 'region_id' or 'region_name' must be specified, unless the provider sets a default region.",
+				ConflictsWith: []string{"region_name"},
 			},
 			"region_name": {
-				Type:         schema.TypeString,
-				Optional:     true,
-				Description:  "The name of the region. Either 'region_id' or 'region_name' must be specified.",
-				ExactlyOneOf: []string{"region_id", "region_name"},
+				Type:          schema.TypeString,
+				Optional:      true,
+				Description:   "The name of the region. Either 'region_id' or 'region_name' must be specified, unless the provider sets a default region.",
+				ConflictsWith: []string{"region_id"},
 			},
 			"name": {
 				Type:        schema.TypeString,
@@ -107,6 +112,14 @@ func resourceNetwork() *schema.Resource {
 					Type: schema.TypeString,
 				},
 			},
+			"metadata_all": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Description: "A map containing all metadata of the resource, including the provider `default_metadata`.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
 			"metadata_read_only": {
 				Type:        schema.TypeList,
 				Computed:    true,
@@ -136,9 +149,8 @@ func resourceNetworkCreate(ctx context.Context, d *schema.ResourceData, m interf
 	log.Println("[DEBUG] Start Network creating")
 	var diags diag.Diagnostics
 	config := m.(*Config)
-	provider := config.Provider
 
-	client, err := CreateClient(provider, d, NetworksPoint, VersionPointV1)
+	client, err := CreateClient(config, d, NetworksPoint, VersionPointV1)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -149,7 +161,7 @@ func resourceNetworkCreate(ctx context.Context, d *schema.ResourceData, m interf
 		CreateRouter: d.Get("create_router").(bool),
 	}
 
-	if metadataRaw, ok := d.GetOk("metadata_map"); ok {
+	if metadataRaw := metadataWithDefaults(config, d.Get("metadata_map")); len(metadataRaw) > 0 {
 		meta, err := utils.MapInterfaceToMapString(metadataRaw)
 		if err != nil {
 			return diag.FromErr(err)
@@ -166,7 +178,7 @@ func resourceNetworkCreate(ctx context.Context, d *schema.ResourceData, m interf
 
 	taskID := results.Tasks[0]
 	log.Printf("[DEBUG] Task id (%s)", taskID)
-	networkID, err := tasks.WaitTaskAndReturnResult(client, taskID, true, NetworkCreatingTimeout, func(task tasks.TaskID) (interface{}, error) {
+	networkID, err := waitTaskAndReturnResult(ctx, client, taskID, d.Timeout(schema.TimeoutCreate), func(task tasks.TaskID) (interface{}, error) {
 		taskInfo, err := tasks.Get(client, string(task)).Extract()
 		if err != nil {
 			return nil, fmt.Errorf("cannot get task with ID: %s. Error: %w", task, err)
@@ -196,17 +208,19 @@ func resourceNetworkRead(_ context.Context, d *schema.ResourceData, m interface{
 	log.Printf("[DEBUG] Start network reading%s", d.State())
 	var diags diag.Diagnostics
 	config := m.(*Config)
-	provider := config.Provider
 	networkID := d.Id()
 	log.Printf("[DEBUG] Network id = %s", networkID)
 
-	client, err := CreateClient(provider, d, NetworksPoint, VersionPointV1)
+	client, err := CreateClient(config, d, NetworksPoint, VersionPointV1)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
 	network, err := networks.Get(client, networkID).Extract()
 	if err != nil {
+		if removeIfNotFound(d, "network", err) {
+			return nil
+		}
 		return diag.Errorf("cannot get network with ID: %s. Error: %s", networkID, err)
 	}
 
@@ -218,7 +232,10 @@ func resourceNetworkRead(_ context.Context, d *schema.ResourceData, m interface{
 
 	metadataMap, metadataReadOnly := PrepareMetadata(network.Metadata)
 
-	if err = d.Set("metadata_map", metadataMap); err != nil {
+	if err = d.Set("metadata_map", metadataWithoutDefaults(config, metadataMap, d.Get("metadata_map"))); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("metadata_all", metadataMap); err != nil {
 		return diag.FromErr(err)
 	}
 
@@ -239,8 +256,7 @@ func resourceNetworkUpdate(ctx context.Context, d *schema.ResourceData, m interf
 	networkID := d.Id()
 	log.Printf("[DEBUG] Volume id = %s", networkID)
 	config := m.(*Config)
-	provider := config.Provider
-	client, err := CreateClient(provider, d, NetworksPoint, VersionPointV1)
+	client, err := CreateClient(config, d, NetworksPoint, VersionPointV1)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -253,10 +269,10 @@ func resourceNetworkUpdate(ctx context.Context, d *schema.ResourceData, m interf
 		}
 	}
 
-	if d.HasChange("metadata_map") {
-		_, nmd := d.GetChange("metadata_map")
+	if d.HasChanges("metadata_map", "metadata_all") {
+		nmd := metadataWithDefaults(config, d.Get("metadata_map"))
 
-		meta, err := utils.MapInterfaceToMapString(nmd.(map[string]interface{}))
+		meta, err := utils.MapInterfaceToMapString(nmd)
 		if err != nil {
 			return diag.Errorf("cannot get metadata. Error: %s", err)
 		}
@@ -272,15 +288,14 @@ func resourceNetworkUpdate(ctx context.Context, d *schema.ResourceData, m interf
 	return resourceNetworkRead(ctx, d, m)
 }
 
-func resourceNetworkDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+func resourceNetworkDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	log.Println("[DEBUG] Start network deleting")
 	var diags diag.Diagnostics
 	config := m.(*Config)
-	provider := config.Provider
 	networkID := d.Id()
 	log.Printf("[DEBUG] Network id = %s", networkID)
 
-	client, err := CreateClient(provider, d, NetworksPoint, VersionPointV1)
+	client, err := CreateClient(config, d, NetworksPoint, VersionPointV1)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -291,13 +306,12 @@ func resourceNetworkDelete(_ context.Context, d *schema.ResourceData, m interfac
 	}
 	taskID := results.Tasks[0]
 	log.Printf("[DEBUG] Task id (%s)", taskID)
-	_, err = tasks.WaitTaskAndReturnResult(client, taskID, true, NetworkDeleting, func(task tasks.TaskID) (interface{}, error) {
+	_, err = waitTaskAndReturnResult(ctx, client, taskID, d.Timeout(schema.TimeoutDelete), func(task tasks.TaskID) (interface{}, error) {
 		_, err := networks.Get(client, networkID).Extract()
 		if err == nil {
 			return nil, fmt.Errorf("cannot delete network with ID: %s", networkID)
 		}
-		var errDefault404 edgecloud.Default404Error
-		if errors.As(err, &errDefault404) {
+		if isNotFound(err) {
 			return nil, nil
 		}
 		return nil, fmt.Errorf("extracting Network resource error: %w", err)
@@ -2,7 +2,6 @@ package edgecenter
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"log"
 	"net"
@@ -12,7 +11,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
-	edgecloud "github.com/Edge-Center/edgecentercloud-go"
 	"github.com/Edge-Center/edgecentercloud-go/edgecenter/port/v1/ports"
 	"github.com/Edge-Center/edgecentercloud-go/edgecenter/reservedfixedip/v1/reservedfixedips"
 	"github.com/Edge-Center/edgecentercloud-go/edgecenter/task/v1/tasks"
@@ -31,9 +29,13 @@ func resourceReservedFixedIP() *schema.Resource {
 		UpdateContext: resourceReservedFixedIPUpdate,
 		DeleteContext: resourceReservedFixedIPDelete,
 		Description:   "Represent reserved ips",
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(time.Duration(ReservedFixedIPCreateTimeout) * time.Second),
+			Delete: schema.DefaultTimeout(time.Duration(ReservedFixedIPCreateTimeout) * time.Second),
+		},
 		Importer: &schema.ResourceImporter{
 			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-				projectID, regionID, ipID, err := ImportStringParser(d.Id())
+				projectID, regionID, ipID, err := ImportStringParser(meta.(*Config), d.Id())
 				if err != nil {
 					return nil, err
 				}
@@ -47,28 +49,30 @@ func resourceReservedFixedIP() *schema.Resource {
 
 		Schema: map[string]*schema.Schema{
 			"project_id": {
-				Type:         schema.TypeInt,
-				Optional:     true,
-				Description:  "The uuid of the project. Either 'project_id' or 'project_name' must be specified.",
-				ExactlyOneOf: []string{"project_id", "project_name"},
+				Type:          schema.TypeInt,
+				Optional:      true,
+				Computed:      true,
+				Description:   "The ID of the project. Either 'project_id' or 'project_name' must be specified, unless the provider sets a default project.",
+				ConflictsWith: []string{"project_name"},
 			},
 			"project_name": {
-				Type:         schema.TypeString,
-				Optional:     true,
-				Description:  "The name of the project. Either 'project_id' or 'project_name' must be specified.",
-				ExactlyOneOf: []string{"project_id", "project_name"},
+				Type:          schema.TypeString,
+				Optional:      true,
+				Description:   "The name of the project. Either 'project_id' or 'project_name' must be specified, unless the provider sets a default project.",
+				ConflictsWith: []string{"project_id"},
 			},
 			"region_id": {
-				Type:         schema.TypeInt,
-				Optional:     true,
-				Description:  "The uuid of the region. Either 'region_id' or 'region_name' must be specified.",
-				ExactlyOneOf: []string{"region_id", "region_name"},
+				Type:          schema.TypeInt,
+				Optional:      true,
+				Computed:      true,
+				Description:   "The ID of the region. Either 'region_id' or 'region_name' must be specified, unless the provider sets a default region.",
+				ConflictsWith: []string{"region_name"},
 			},
 			"region_name": {
-				Type:         schema.TypeString,
-				Optional:     true,
-				Description:  "The name of the region. Either 'region_id' or 'region_name' must be specified.",
-				ExactlyOneOf: []string{"region_id", "region_name"},
+				Type:          schema.TypeString,
+				Optional:      true,
+				Description:   "The name of the region. Either 'region_id' or 'region_name' must be specified, unless the provider sets a default region.",
+				ConflictsWith: []string{"region_id"},
 			},
 			"type": {
 				Type:        schema.TypeString,
@@ -160,9 +164,8 @@ func resourceReservedFixedIPCreate(ctx context.Context, d *schema.ResourceData,
 	log.Println("[DEBUG] Start ReservedFixedIP creating")
 	var diags diag.Diagnostics
 	config := m.(*Config)
-	provider := config.Provider
 
-	client, err := CreateClient(provider, d, ReservedFixedIPsPoint, VersionPointV1)
+	client, err := CreateClient(config, d, ReservedFixedIPsPoint, VersionPointV1)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -207,7 +210,7 @@ func resourceReservedFixedIPCreate(ctx context.Context, d *schema.ResourceData,
 	}
 
 	taskID := results.Tasks[0]
-	reservedFixedIPID, err := tasks.WaitTaskAndReturnResult(client, taskID, true, ReservedFixedIPCreateTimeout, func(task tasks.TaskID) (interface{}, error) {
+	reservedFixedIPID, err := waitTaskAndReturnResult(ctx, client, taskID, d.Timeout(schema.TimeoutCreate), func(task tasks.TaskID) (interface{}, error) {
 		taskInfo, err := tasks.Get(client, string(task)).Extract()
 		if err != nil {
 			return nil, fmt.Errorf("cannot get task with ID: %s. Error: %w", task, err)
@@ -236,19 +239,15 @@ func resourceReservedFixedIPRead(_ context.Context, d *schema.ResourceData, m in
 	log.Println("[DEBUG] Start ReservedFixedIP reading")
 	var diags diag.Diagnostics
 	config := m.(*Config)
-	provider := config.Provider
 
-	client, err := CreateClient(provider, d, ReservedFixedIPsPoint, VersionPointV1)
+	client, err := CreateClient(config, d, ReservedFixedIPsPoint, VersionPointV1)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
 	reservedFixedIP, err := reservedfixedips.Get(client, d.Id()).Extract()
 	if err != nil {
-		var errDefault404 edgecloud.Default404Error
-		if errors.As(err, &errDefault404) {
-			log.Printf("[WARN] Removing reserved fixed ip %s because resource doesn't exist anymore", d.Id())
-			d.SetId("")
+		if removeIfNotFound(d, "reserved fixed ip", err) {
 			return nil
 		}
 		return diag.FromErr(err)
@@ -286,9 +285,8 @@ func resourceReservedFixedIPRead(_ context.Context, d *schema.ResourceData, m in
 func resourceReservedFixedIPUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	log.Println("[DEBUG] Start ReservedFixedIP updating")
 	config := m.(*Config)
-	provider := config.Provider
 
-	client, err := CreateClient(provider, d, ReservedFixedIPsPoint, VersionPointV1)
+	client, err := CreateClient(config, d, ReservedFixedIPsPoint, VersionPointV1)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -313,7 +311,7 @@ func resourceReservedFixedIPUpdate(ctx context.Context, d *schema.ResourceData,
 			}
 		}
 
-		clientPort, err := CreateClient(provider, d, portsPoint, VersionPointV1)
+		clientPort, err := CreateClient(config, d, portsPoint, VersionPointV1)
 		if err != nil {
 			return diag.FromErr(err)
 		}
@@ -330,13 +328,12 @@ func resourceReservedFixedIPUpdate(ctx context.Context, d *schema.ResourceData,
 	return resourceReservedFixedIPRead(ctx, d, m)
 }
 
-func resourceReservedFixedIPDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+func resourceReservedFixedIPDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	log.Println("[DEBUG] Start ReservedFixedIP deleting")
 	var diags diag.Diagnostics
 	config := m.(*Config)
-	provider := config.Provider
 
-	client, err := CreateClient(provider, d, ReservedFixedIPsPoint, VersionPointV1)
+	client, err := CreateClient(config, d, ReservedFixedIPsPoint, VersionPointV1)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -350,8 +347,7 @@ func resourceReservedFixedIPDelete(_ context.Context, d *schema.ResourceData, m
 	id := d.Id()
 	results, err := reservedfixedips.Delete(client, id).Extract()
 	if err != nil {
-		var errDefault404 edgecloud.Default404Error
-		if errors.As(err, &errDefault404) {
+		if isNotFound(err) {
 			d.SetId("")
 			log.Printf("[DEBUG] Finish of ReservedFixedIP deleting")
 			return diags
@@ -360,13 +356,12 @@ func resourceReservedFixedIPDelete(_ context.Context, d *schema.ResourceData, m
 	}
 
 	taskID := results.Tasks[0]
-	_, err = tasks.WaitTaskAndReturnResult(client, taskID, true, ReservedFixedIPCreateTimeout, func(task tasks.TaskID) (interface{}, error) {
+	_, err = waitTaskAndReturnResult(ctx, client, taskID, d.Timeout(schema.TimeoutDelete), func(task tasks.TaskID) (interface{}, error) {
 		_, err := reservedfixedips.Get(client, id).Extract()
 		if err == nil {
 			return nil, fmt.Errorf("cannot delete reserved fixed ip with ID: %s", id)
 		}
-		var errDefault404 edgecloud.Default404Error
-		if errors.As(err, &errDefault404) {
+		if isNotFound(err) {
 			return nil, nil
 		}
 		return nil, fmt.Errorf("extracting FixedIP resource error: %w", err)
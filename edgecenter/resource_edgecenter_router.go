@@ -2,7 +2,6 @@ package edgecenter
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"log"
 	"time"
@@ -10,7 +9,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
-	edgecloud "github.com/Edge-Center/edgecentercloud-go"
 	"github.com/Edge-Center/edgecentercloud-go/edgecenter/router/v1/routers"
 	"github.com/Edge-Center/edgecentercloud-go/edgecenter/subnet/v1/subnets"
 	"github.com/Edge-Center/edgecentercloud-go/edgecenter/task/v1/tasks"
@@ -29,9 +27,13 @@ func resourceRouter() *schema.Resource {
 		UpdateContext: resourceRouterUpdate,
 		DeleteContext: resourceRouterDelete,
 		Description:   "Represent router. Router enables you to dynamically exchange routes between networks",
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(time.Duration(RouterCreatingTimeout) * time.Second),
+			Delete: schema.DefaultTimeout(time.Duration(RouterDeleting) * time.Second),
+		},
 		Importer: &schema.ResourceImporter{
 			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-				projectID, regionID, routerID, err := ImportStringParser(d.Id())
+				projectID, regionID, routerID, err := ImportStringParser(meta.(*Config), d.Id())
 				if err != nil {
 					return nil, err
 				}
@@ -45,28 +47,30 @@ func resourceRouter() *schema.Resource {
 
 		Schema: map[string]*schema.Schema{
 			"project_id": {
-				Type:         schema.TypeInt,
-				Optional:     true,
-				Description:  "The uuid of the project. Either 'project_id' or 'project_name' must be specified.",
-				ExactlyOneOf: []string{"project_id", "project_name"},
+				Type:          schema.TypeInt,
+				Optional:      true,
+				Computed:      true,
+				Description:   "The ID of the project. Either 'project_id' or 'project_name' must be specified, unless the provider sets a default project.",
+				ConflictsWith: []string{"project_name"},
 			},
 			"project_name": {
-				Type:         schema.TypeString,
-				Optional:     true,
-				Description:  "The name of the project. Either 'project_id' or 'project_name' must be specified.",
-				ExactlyOneOf: []string{"project_id", "project_name"},
+				Type:          schema.TypeString,
+				Optional:      true,
+				Description:   "The name of the project. Either 'project_id' or 'project_name' must be specified, unless the provider sets a default project.",
+				ConflictsWith: []string{"project_id"},
 			},
 			"region_id": {
-				Type:         schema.TypeInt,
-				Optional:     true,
-				Description:  "The uuid of the region. Either 'region_id' or 'region_name' must be specified.",
-				ExactlyOneOf: []string{"region_id", "region_name"},
+				Type:          schema.TypeInt,
+				Optional:      true,
+				Computed:      true,
+				Description:   "The ID of the region. Either 'region_id' or 'region_name' must be specified, unless the provider sets a default region.",
+				ConflictsWith: []string{"region_name"},
 			},
 			"region_name": {
-				Type:         schema.TypeString,
-				Optional:     true,
-				Description:  "The name of the region. Either 'region_id' or 'region_name' must be specified.",
-				ExactlyOneOf: []string{"region_id", "region_name"},
+				Type:          schema.TypeString,
+				Optional:      true,
+				Description:   "The name of the region. Either 'region_id' or 'region_name' must be specified, unless the provider sets a default region.",
+				ConflictsWith: []string{"region_id"},
 			},
 			"name": {
 				Type:        schema.TypeString,
@@ -185,9 +189,8 @@ func resourceRouterCreate(ctx context.Context, d *schema.ResourceData, m interfa
 	log.Println("[DEBUG] Start router creating")
 	var diags diag.Diagnostics
 	config := m.(*Config)
-	provider := config.Provider
 
-	client, err := CreateClient(provider, d, RouterPoint, VersionPointV1)
+	client, err := CreateClient(config, d, RouterPoint, VersionPointV1)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -230,7 +233,7 @@ func resourceRouterCreate(ctx context.Context, d *schema.ResourceData, m interfa
 
 	taskID := results.Tasks[0]
 	log.Printf("[DEBUG] Task id (%s)", taskID)
-	routerID, err := tasks.WaitTaskAndReturnResult(client, taskID, true, RouterCreatingTimeout, func(task tasks.TaskID) (interface{}, error) {
+	routerID, err := waitTaskAndReturnResult(ctx, client, taskID, d.Timeout(schema.TimeoutCreate), func(task tasks.TaskID) (interface{}, error) {
 		taskInfo, err := tasks.Get(client, string(task)).Extract()
 		if err != nil {
 			return nil, fmt.Errorf("cannot get task with ID: %s. Error: %w", task, err)
@@ -260,17 +263,19 @@ func resourceRouterRead(_ context.Context, d *schema.ResourceData, m interface{}
 	log.Printf("[DEBUG] Start router reading%s", d.State())
 	var diags diag.Diagnostics
 	config := m.(*Config)
-	provider := config.Provider
 	routerID := d.Id()
 	log.Printf("[DEBUG] Router id = %s", routerID)
 
-	client, err := CreateClient(provider, d, RouterPoint, VersionPointV1)
+	client, err := CreateClient(config, d, RouterPoint, VersionPointV1)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
 	router, err := routers.Get(client, routerID).Extract()
 	if err != nil {
+		if removeIfNotFound(d, "router", err) {
+			return nil
+		}
 		return diag.Errorf("cannot get router with ID: %s. Error: %s", routerID, err)
 	}
 
@@ -340,8 +345,7 @@ func resourceRouterUpdate(ctx context.Context, d *schema.ResourceData, m interfa
 	routerID := d.Id()
 	log.Printf("[DEBUG] Router id = %s", routerID)
 	config := m.(*Config)
-	provider := config.Provider
-	client, err := CreateClient(provider, d, RouterPoint, VersionPointV1)
+	client, err := CreateClient(config, d, RouterPoint, VersionPointV1)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -427,15 +431,14 @@ func resourceRouterUpdate(ctx context.Context, d *schema.ResourceData, m interfa
 	return resourceRouterRead(ctx, d, m)
 }
 
-func resourceRouterDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+func resourceRouterDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	log.Println("[DEBUG] Start router deleting")
 	var diags diag.Diagnostics
 	config := m.(*Config)
-	provider := config.Provider
 	routerID := d.Id()
 	log.Printf("[DEBUG] Router id = %s", routerID)
 
-	client, err := CreateClient(provider, d, RouterPoint, VersionPointV1)
+	client, err := CreateClient(config, d, RouterPoint, VersionPointV1)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -446,13 +449,12 @@ func resourceRouterDelete(_ context.Context, d *schema.ResourceData, m interface
 	}
 	taskID := results.Tasks[0]
 	log.Printf("[DEBUG] Task id (%s)", taskID)
-	_, err = tasks.WaitTaskAndReturnResult(client, taskID, true, RouterDeleting, func(task tasks.TaskID) (interface{}, error) {
+	_, err = waitTaskAndReturnResult(ctx, client, taskID, d.Timeout(schema.TimeoutDelete), func(task tasks.TaskID) (interface{}, error) {
 		_, err := routers.Get(client, routerID).Extract()
 		if err == nil {
 			return nil, fmt.Errorf("cannot delete router with ID: %s", routerID)
 		}
-		var errDefault404 edgecloud.Default404Error
-		if errors.As(err, &errDefault404) {
+		if isNotFound(err) {
 			return nil, nil
 		}
 		return nil, fmt.Errorf("extracting Router resource error: %w", err)
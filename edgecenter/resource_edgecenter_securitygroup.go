@@ -28,10 +28,11 @@ func resourceSecurityGroup() *schema.Resource {
 		ReadContext:   resourceSecurityGroupRead,
 		UpdateContext: resourceSecurityGroupUpdate,
 		DeleteContext: resourceSecurityGroupDelete,
+		CustomizeDiff: customizeDiffMetadataAll,
 		Description:   "Represent SecurityGroups(Firewall)",
 		Importer: &schema.ResourceImporter{
 			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-				projectID, regionID, sgID, err := ImportStringParser(d.Id())
+				projectID, regionID, sgID, err := ImportStringParser(meta.(*Config), d.Id())
 				if err != nil {
 					return nil, err
 				}
@@ -45,28 +46,30 @@ func resourceSecurityGroup() *schema.Resource {
 
 		Schema: map[string]*schema.Schema{
 			"project_id": {
-				Type:         schema.TypeInt,
-				Optional:     true,
-				Description:  "The uuid of the project. Either 'project_id' or 'project_name' must be specified.",
-				ExactlyOneOf: []string{"project_id", "project_name"},
+				Type:          schema.TypeInt,
+				Optional:      true,
+				Computed:      true,
+				Description:   "The ID of the project. Either 'project_id' or 'project_name' must be specified, unless the provider sets a default project.",
+				ConflictsWith: []string{"project_name"},
 			},
 			"project_name": {
-				Type:         schema.TypeString,
-				Optional:     true,
-				Description:  "The name of the project. Either 'project_id' or 'project_name' must be specified.",
-				ExactlyOneOf: []string{"project_id", "project_name"},
+				Type:          schema.TypeString,
+				Optional:      true,
+				Description:   "The name of the project. Either 'project_id' or 'project_name' must be specified, unless the provider sets a default project.",
+				ConflictsWith: []string{"project_id"},
 			},
 			"region_id": {
-				Type:         schema.TypeInt,
-				Optional:     true,
-				Description:  "The uuid of the region. Either 'region_id' or 'region_name' must be specified.",
-				ExactlyOneOf: []string{"region_id", "region_name"},
+				Type:          schema.TypeInt,
+				Optional:      true,
+				Computed:      true,
+				Description:   "The ID of the region. Either 'region_id' or 'region_name' must be specified, unless the provider sets a default region.",
+				ConflictsWith: []string{"region_name"},
 			},
 			"region_name": {
-				Type:         schema.TypeString,
-				Optional:     true,
-				Description:  "The name of the region. Either 'region_id' or 'region_name' must be specified.",
-				ExactlyOneOf: []string{"region_id", "region_name"},
+				Type:          schema.TypeString,
+				Optional:      true,
+				Description:   "The name of the region. Either 'region_id' or 'region_name' must be specified, unless the provider sets a default region.",
+				ConflictsWith: []string{"region_id"},
 			},
 			"name": {
 				Type:        schema.TypeString,
@@ -86,6 +89,14 @@ func resourceSecurityGroup() *schema.Resource {
 					Type: schema.TypeString,
 				},
 			},
+			"metadata_all": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Description: "A map containing all metadata of the resource, including the provider `default_metadata`.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
 			"metadata_read_only": {
 				Type:        schema.TypeList,
 				Computed:    true,
@@ -210,9 +221,8 @@ func resourceSecurityGroupCreate(ctx context.Context, d *schema.ResourceData, m
 
 	var diags diag.Diagnostics
 	config := m.(*Config)
-	provider := config.Provider
 
-	client, err := CreateClient(provider, d, SecurityGroupPoint, VersionPointV1)
+	client, err := CreateClient(config, d, SecurityGroupPoint, VersionPointV1)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -253,8 +263,8 @@ func resourceSecurityGroupCreate(ctx context.Context, d *schema.ResourceData, m
 	createSecurityGroupOpts.Name = d.Get("name").(string)
 	createSecurityGroupOpts.SecurityGroupRules = rules
 
-	if metadataRaw, ok := d.GetOk("metadata_map"); ok {
-		createSecurityGroupOpts.Metadata = metadataRaw.(map[string]interface{})
+	if metadataRaw := metadataWithDefaults(config, d.Get("metadata_map")); len(metadataRaw) > 0 {
+		createSecurityGroupOpts.Metadata = metadataRaw
 	}
 
 	opts := securitygroups.CreateOpts{
@@ -282,15 +292,17 @@ func resourceSecurityGroupRead(_ context.Context, d *schema.ResourceData, m inte
 	log.Println("[DEBUG] Start SecurityGroup reading")
 	var diags diag.Diagnostics
 	config := m.(*Config)
-	provider := config.Provider
 
-	client, err := CreateClient(provider, d, SecurityGroupPoint, VersionPointV1)
+	client, err := CreateClient(config, d, SecurityGroupPoint, VersionPointV1)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
 	sg, err := securitygroups.Get(client, d.Id()).Extract()
 	if err != nil {
+		if removeIfNotFound(d, "security group", err) {
+			return nil
+		}
 		return diag.FromErr(err)
 	}
 
@@ -313,7 +325,10 @@ func resourceSecurityGroupRead(_ context.Context, d *schema.ResourceData, m inte
 		}
 	}
 
-	if err := d.Set("metadata_map", metadataMap); err != nil {
+	if err := d.Set("metadata_map", metadataWithoutDefaults(config, metadataMap, d.Get("metadata_map"))); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("metadata_all", metadataMap); err != nil {
 		return diag.FromErr(err)
 	}
 	if err := d.Set("metadata_read_only", metadataReadOnly); err != nil {
@@ -386,13 +401,12 @@ func resourceSecurityGroupUpdate(ctx context.Context, d *schema.ResourceData, m
 	}
 
 	config := m.(*Config)
-	provider := config.Provider
-	clientCreate, err := CreateClient(provider, d, SecurityGroupPoint, VersionPointV1)
+	clientCreate, err := CreateClient(config, d, SecurityGroupPoint, VersionPointV1)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	clientUpdateDelete, err := CreateClient(provider, d, securityGroupRulesPoint, VersionPointV1)
+	clientUpdateDelete, err := CreateClient(config, d, securityGroupRulesPoint, VersionPointV1)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -443,10 +457,10 @@ func resourceSecurityGroupUpdate(ctx context.Context, d *schema.ResourceData, m
 		}
 	}
 
-	if d.HasChange("metadata_map") {
-		_, nmd := d.GetChange("metadata_map")
+	if d.HasChanges("metadata_map", "metadata_all") {
+		nmd := metadataWithDefaults(config, d.Get("metadata_map"))
 
-		err := securitygroups.MetadataReplace(clientCreate, gid, nmd.(map[string]interface{})).Err
+		err := securitygroups.MetadataReplace(clientCreate, gid, nmd).Err
 		if err != nil {
 			return diag.Errorf("cannot update metadata. Error: %s", err)
 		}
@@ -462,10 +476,9 @@ func resourceSecurityGroupDelete(_ context.Context, d *schema.ResourceData, m in
 	log.Println("[DEBUG] Start SecurityGroup deleting")
 	var diags diag.Diagnostics
 	config := m.(*Config)
-	provider := config.Provider
 	sgID := d.Id()
 
-	client, err := CreateClient(provider, d, SecurityGroupPoint, VersionPointV1)
+	client, err := CreateClient(config, d, SecurityGroupPoint, VersionPointV1)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -2,8 +2,10 @@ package edgecenter
 
 import (
 	"context"
+	"fmt"
 	"log"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
@@ -22,7 +24,7 @@ func resourceServerGroup() *schema.Resource {
 		Description:   "Represent server group resource",
 		Importer: &schema.ResourceImporter{
 			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-				projectID, regionID, sgID, err := ImportStringParser(d.Id())
+				projectID, regionID, sgID, err := ImportStringParser(meta.(*Config), d.Id())
 				if err != nil {
 					return nil, err
 				}
@@ -36,32 +38,34 @@ func resourceServerGroup() *schema.Resource {
 
 		Schema: map[string]*schema.Schema{
 			"project_id": {
-				Type:         schema.TypeInt,
-				Optional:     true,
-				ForceNew:     true,
-				Description:  "The uuid of the project. Either 'project_id' or 'project_name' must be specified.",
-				ExactlyOneOf: []string{"project_id", "project_name"},
+				Type:          schema.TypeInt,
+				Optional:      true,
+				Computed:      true,
+				ForceNew:      true,
+				Description:   "The ID of the project. Either 'project_id' or 'project_name' must be specified, unless the provider sets a default project.",
+				ConflictsWith: []string{"project_name"},
 			},
 			"project_name": {
-				Type:         schema.TypeString,
-				Optional:     true,
-				ForceNew:     true,
-				Description:  "The name of the project. Either 'project_id' or 'project_name' must be specified.",
-				ExactlyOneOf: []string{"project_id", "project_name"},
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				Description:   "The name of the project. Either 'project_id' or 'project_name' must be specified, unless the provider sets a default project.",
+				ConflictsWith: []string{"project_id"},
 			},
 			"region_id": {
-				Type:         schema.TypeInt,
-				Optional:     true,
-				ForceNew:     true,
-				Description:  "The uuid of the region. Either 'region_id' or 'region_name' must be specified.",
-				ExactlyOneOf: []string{"region_id", "region_name"},
+				Type:          schema.TypeInt,
+				Optional:      true,
+				Computed:      true,
+				ForceNew:      true,
+				Description:   "The ID of the region. Either 'region_id' or 'region_name' must be specified, unless the provider sets a default region.",
+				ConflictsWith: []string{"region_name"},
 			},
 			"region_name": {
-				Type:         schema.TypeString,
-				Optional:     true,
-				ForceNew:     true,
-				Description:  "The name of the region. Either 'region_id' or 'region_name' must be specified.",
-				ExactlyOneOf: []string{"region_id", "region_name"},
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				Description:   "The name of the region. Either 'region_id' or 'region_name' must be specified, unless the provider sets a default region.",
+				ConflictsWith: []string{"region_id"},
 			},
 			"name": {
 				Type:        schema.TypeString,
@@ -70,10 +74,11 @@ func resourceServerGroup() *schema.Resource {
 				ForceNew:    true,
 			},
 			"policy": {
-				Type:        schema.TypeString,
-				Description: "Server group policy. Available value is 'affinity', 'anti-affinity'",
-				Required:    true,
-				ForceNew:    true,
+				Type:             schema.TypeString,
+				Description:      "Server group policy. Available value is 'affinity', 'anti-affinity'",
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validateServerGroupPolicy,
 			},
 			"instances": {
 				Type:        schema.TypeList,
@@ -100,9 +105,8 @@ func resourceServerGroupCreate(ctx context.Context, d *schema.ResourceData, m in
 	log.Println("[DEBUG] Start ServerGroup creating")
 	var diags diag.Diagnostics
 	config := m.(*Config)
-	provider := config.Provider
 
-	client, err := CreateClient(provider, d, ServerGroupsPoint, VersionPointV1)
+	client, err := CreateClient(config, d, ServerGroupsPoint, VersionPointV1)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -128,15 +132,17 @@ func resourceServerGroupRead(_ context.Context, d *schema.ResourceData, m interf
 	log.Println("[DEBUG] Start ServerGroup reading")
 	var diags diag.Diagnostics
 	config := m.(*Config)
-	provider := config.Provider
 
-	client, err := CreateClient(provider, d, ServerGroupsPoint, VersionPointV1)
+	client, err := CreateClient(config, d, ServerGroupsPoint, VersionPointV1)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
 	serverGroup, err := servergroups.Get(client, d.Id()).Extract()
 	if err != nil {
+		if removeIfNotFound(d, "server group", err) {
+			return nil
+		}
 		return diag.FromErr(err)
 	}
 
@@ -165,9 +171,8 @@ func resourceServerGroupDelete(_ context.Context, d *schema.ResourceData, m inte
 	log.Println("[DEBUG] Start ServerGroup deleting")
 	var diags diag.Diagnostics
 	config := m.(*Config)
-	provider := config.Provider
 
-	client, err := CreateClient(provider, d, ServerGroupsPoint, VersionPointV1)
+	client, err := CreateClient(config, d, ServerGroupsPoint, VersionPointV1)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -182,3 +187,19 @@ func resourceServerGroupDelete(_ context.Context, d *schema.ResourceData, m inte
 
 	return diags
 }
+
+// validateServerGroupPolicy checks the policy against the ones the platform supports.
+func validateServerGroupPolicy(i interface{}, path cty.Path) diag.Diagnostics {
+	policy := servergroups.ServerGroupPolicy(i.(string))
+	if err := policy.IsValid(); err != nil {
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("invalid server group policy %q", policy),
+			Detail: fmt.Sprintf(`The policy must be one of %q. 'affinity' keeps the members on one host, 'anti-affinity' keeps each member on a separate host,
+so an anti-affinity group can't hold more members than the region has hosts for the flavor.`, policy.StringList()),
+			AttributePath: path,
+		}}
+	}
+
+	return nil
+}
@@ -0,0 +1,214 @@
+package edgecenter
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/Edge-Center/edgecentercloud-go/edgecenter/servergroup/v1/servergroups"
+)
+
+const (
+	serverGroupMemberTimeout int = 1200
+)
+
+func resourceServerGroupMember() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceServerGroupMemberCreate,
+		ReadContext:   resourceServerGroupMemberRead,
+		DeleteContext: resourceServerGroupMemberDelete,
+		Description: `Places an existing instance into a server group without changing the instance,
+so the placement may be managed separately, e.g. by the module which owns the group.
+Don't use it together with 'server_group' of the same edgecenter_instance.`,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(time.Duration(serverGroupMemberTimeout) * time.Second),
+			Delete: schema.DefaultTimeout(time.Duration(serverGroupMemberTimeout) * time.Second),
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				projectID, regionID, serverGroupID, instanceID, err := ImportStringParserExtended(meta.(*Config), d.Id())
+				if err != nil {
+					return nil, err
+				}
+				d.Set("project_id", projectID)
+				d.Set("region_id", regionID)
+				d.Set("servergroup_id", serverGroupID)
+				d.Set("instance_id", instanceID)
+				d.SetId(instanceID)
+
+				return []*schema.ResourceData{d}, nil
+			},
+		},
+
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:          schema.TypeInt,
+				Optional:      true,
+				Computed:      true,
+				ForceNew:      true,
+				Description:   "The ID of the project. Either 'project_id' or 'project_name' must be specified, unless the provider sets a default project.",
+				ConflictsWith: []string{"project_name"},
+			},
+			"project_name": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				Description:   "The name of the project. Either 'project_id' or 'project_name' must be specified, unless the provider sets a default project.",
+				ConflictsWith: []string{"project_id"},
+			},
+			"region_id": {
+				Type:          schema.TypeInt,
+				Optional:      true,
+				Computed:      true,
+				ForceNew:      true,
+				Description:   "The ID of the region. Either 'region_id' or 'region_name' must be specified, unless the provider sets a default region.",
+				ConflictsWith: []string{"region_name"},
+			},
+			"region_name": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				Description:   "The name of the region. Either 'region_id' or 'region_name' must be specified, unless the provider sets a default region.",
+				ConflictsWith: []string{"region_id"},
+			},
+			"servergroup_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The uuid of the server group.",
+			},
+			"instance_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The uuid of the instance to place into the server group. An instance belongs to one server group at most.",
+			},
+			"instance_name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The name of the instance.",
+			},
+			"policy": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The policy of the server group.",
+			},
+		},
+	}
+}
+
+func resourceServerGroupMemberCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Println("[DEBUG] Start ServerGroup member creating")
+	config := m.(*Config)
+
+	sgClient, err := CreateClient(config, d, ServerGroupsPoint, VersionPointV1)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	instanceClient, err := CreateClient(config, d, InstancePoint, VersionPointV1)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	serverGroupID := d.Get("servergroup_id").(string)
+	instanceID := d.Get("instance_id").(string)
+	serverGroup, err := servergroups.Get(sgClient, serverGroupID).Extract()
+	if err != nil {
+		return diag.Errorf("cannot get server group with ID: %s. Error: %s", serverGroupID, err)
+	}
+
+	if err := addServerGroup(ctx, sgClient, instanceClient, instanceID, serverGroupID, d.Timeout(schema.TimeoutCreate)); err != nil {
+		return serverGroupMemberDiag(serverGroup, err)
+	}
+
+	d.SetId(instanceID)
+	log.Printf("[DEBUG] Finish ServerGroup member creating (%s)", instanceID)
+
+	return resourceServerGroupMemberRead(ctx, d, m)
+}
+
+func resourceServerGroupMemberRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Println("[DEBUG] Start ServerGroup member reading")
+	var diags diag.Diagnostics
+	config := m.(*Config)
+
+	client, err := CreateClient(config, d, ServerGroupsPoint, VersionPointV1)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	serverGroup, err := servergroups.Get(client, d.Get("servergroup_id").(string)).Extract()
+	if err != nil {
+		if removeIfNotFound(d, "server group member", err) {
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	var member *servergroups.ServerGroupInstance
+	for i := range serverGroup.Instances {
+		if serverGroup.Instances[i].InstanceID == d.Id() {
+			member = &serverGroup.Instances[i]
+			break
+		}
+	}
+	if member == nil {
+		log.Printf("[WARN] Removing server group member %s because the instance is not in the server group anymore", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("instance_id", member.InstanceID)
+	d.Set("instance_name", member.InstanceName)
+	d.Set("policy", serverGroup.Policy.String())
+
+	log.Println("[DEBUG] Finish ServerGroup member reading")
+
+	return diags
+}
+
+func resourceServerGroupMemberDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Println("[DEBUG] Start ServerGroup member deleting")
+	var diags diag.Diagnostics
+	config := m.(*Config)
+
+	sgClient, err := CreateClient(config, d, ServerGroupsPoint, VersionPointV1)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	instanceClient, err := CreateClient(config, d, InstancePoint, VersionPointV1)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = deleteServerGroup(ctx, sgClient, instanceClient, d.Id(), d.Get("servergroup_id").(string), d.Timeout(schema.TimeoutDelete))
+	if err != nil && !isNotFound(err) {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	log.Println("[DEBUG] Finish ServerGroup member deleting")
+
+	return diags
+}
+
+// serverGroupMemberDiag explains why an instance could not join the server group.
+func serverGroupMemberDiag(serverGroup *servergroups.ServerGroup, err error) diag.Diagnostics {
+	if serverGroup.Policy != servergroups.AntiAffinityPolicy {
+		return diag.FromErr(err)
+	}
+
+	return diag.Diagnostics{{
+		Severity: diag.Error,
+		Summary:  fmt.Sprintf("cannot place the instance into anti-affinity server group %s: %s", serverGroup.Name, err),
+		Detail: fmt.Sprintf(`The platform keeps each member of an anti-affinity group on a separate host, and can't satisfy the policy
+when the instance shares a host with one of the %d current members or the region has no free host to move it to.
+Create the instance with 'server_group' set instead, so that it is placed when it is scheduled, or use fewer members.`, len(serverGroup.Instances)),
+		AttributePath: cty.GetAttrPath("servergroup_id"),
+	}}
+}
@@ -122,6 +122,7 @@ func resourceStorageS3Create(ctx context.Context, d *schema.ResourceData, m inte
 
 	opts := []func(opt *storages.StorageCreateHTTPParams){
 		func(opt *storages.StorageCreateHTTPParams) { opt.Context = ctx },
+		func(opt *storages.StorageCreateHTTPParams) { opt.HTTPClient = config.StorageHTTPClient },
 		func(opt *storages.StorageCreateHTTPParams) { opt.Body.Type = "s3" },
 	}
 	location := strings.TrimSpace(d.Get(StorageSchemaLocation).(string))
@@ -159,6 +160,7 @@ func resourceStorageS3Read(ctx context.Context, d *schema.ResourceData, m interf
 
 	opts := []func(opt *storages.StorageListHTTPV2Params){
 		func(opt *storages.StorageListHTTPV2Params) { opt.Context = ctx },
+		func(opt *storages.StorageListHTTPV2Params) { opt.HTTPClient = config.StorageHTTPClient },
 		func(opt *storages.StorageListHTTPV2Params) { opt.ShowDeleted = new(bool) },
 	}
 	if resourceID != "" {
@@ -174,9 +176,17 @@ func resourceStorageS3Read(ctx context.Context, d *schema.ResourceData, m interf
 
 	result, err := client.StoragesList(opts...)
 	if err != nil {
+		if removeIfNotFound(d, "storage", err) {
+			return nil
+		}
 		return diag.FromErr(fmt.Errorf("storages list: %w", err))
 	}
 
+	if len(result) == 0 && d.Id() != "" {
+		log.Printf("[WARN] Removing storage %s because resource doesn't exist anymore", d.Id())
+		d.SetId("")
+		return nil
+	}
 	if (len(result) == 0) || (name == "" && len(result) != 1) {
 		return diag.Errorf("get storage: wrong length of search result (%d), want 1", len(result))
 	}
@@ -218,6 +228,7 @@ func resourceStorageS3Delete(ctx context.Context, d *schema.ResourceData, m inte
 
 	opts := []func(opt *storages.StorageDeleteHTTPParams){
 		func(opt *storages.StorageDeleteHTTPParams) { opt.Context = ctx },
+		func(opt *storages.StorageDeleteHTTPParams) { opt.HTTPClient = config.StorageHTTPClient },
 		func(opt *storages.StorageDeleteHTTPParams) { opt.ID = id },
 	}
 	err = client.DeleteStorage(opts...)
@@ -65,6 +65,7 @@ func resourceStorageS3BucketCreate(ctx context.Context, d *schema.ResourceData,
 	opts := []func(opt *buckets.StorageBucketCreateHTTPParams){
 		func(opt *buckets.StorageBucketCreateHTTPParams) {
 			opt.Context = ctx
+			opt.HTTPClient = config.StorageHTTPClient
 			opt.ID = int64(id)
 		},
 	}
@@ -92,16 +93,17 @@ func resourceStorageS3BucketRead(ctx context.Context, d *schema.ResourceData, m
 
 	opts := []func(opt *buckets.StorageListBucketsHTTPParams){
 		func(opt *buckets.StorageListBucketsHTTPParams) { opt.Context = ctx },
+		func(opt *buckets.StorageListBucketsHTTPParams) { opt.HTTPClient = config.StorageHTTPClient },
 		func(opt *buckets.StorageListBucketsHTTPParams) { opt.ID = int64(storageID) },
 	}
 
 	result, err := client.BucketsList(opts...)
 	if err != nil {
+		if removeIfNotFound(d, "storage bucket", err) {
+			return nil
+		}
 		return diag.FromErr(fmt.Errorf("storage buckets list: %w", err))
 	}
-	if len(result) == 0 {
-		return diag.Errorf("get buckets: wrong length of search result (%d), want more", len(result))
-	}
 	for _, bucket := range result {
 		if bucket.Name == bucketName {
 			d.SetId(fmt.Sprintf("%d:%s", storageID, bucketName))
@@ -110,6 +112,11 @@ func resourceStorageS3BucketRead(ctx context.Context, d *schema.ResourceData, m
 			return nil
 		}
 	}
+	if d.Id() != "" {
+		log.Printf("[WARN] Removing storage bucket %s because resource doesn't exist anymore", d.Id())
+		d.SetId("")
+		return nil
+	}
 
 	return diag.FromErr(fmt.Errorf("storage buckets list has not this bucket"))
 }
@@ -127,6 +134,7 @@ func resourceStorageS3BucketDelete(ctx context.Context, d *schema.ResourceData,
 
 	opts := []func(opt *buckets.StorageBucketRemoveHTTPParams){
 		func(opt *buckets.StorageBucketRemoveHTTPParams) { opt.Context = ctx },
+		func(opt *buckets.StorageBucketRemoveHTTPParams) { opt.HTTPClient = config.StorageHTTPClient },
 		func(opt *buckets.StorageBucketRemoveHTTPParams) { opt.ID = int64(storageID) },
 		func(opt *buckets.StorageBucketRemoveHTTPParams) { opt.Name = bucketName },
 	}
@@ -2,7 +2,6 @@ package edgecenter
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"log"
 	"net"
@@ -33,10 +32,15 @@ func resourceSubnet() *schema.Resource {
 		ReadContext:   resourceSubnetRead,
 		UpdateContext: resourceSubnetUpdate,
 		DeleteContext: resourceSubnetDelete,
+		CustomizeDiff: customizeDiffMetadataAll,
 		Description:   "Represent subnets. Subnetwork is a range of IP addresses in a cloud network. Addresses from this range will be assigned to machines in the cloud",
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(time.Duration(SubnetCreatingTimeout) * time.Second),
+			Delete: schema.DefaultTimeout(time.Duration(SubnetDeleting) * time.Second),
+		},
 		Importer: &schema.ResourceImporter{
 			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-				projectID, regionID, subnetID, err := ImportStringParser(d.Id())
+				projectID, regionID, subnetID, err := ImportStringParser(meta.(*Config), d.Id())
 				if err != nil {
 					return nil, err
 				}
@@ -50,28 +54,30 @@ func resourceSubnet() *schema.Resource {
 
 		Schema: map[string]*schema.Schema{
 			"project_id": {
-				Type:         schema.TypeInt,
-				Optional:     true,
-				Description:  "The uuid of the project. Either 'project_id' or 'project_name' must be specified.",
-				ExactlyOneOf: []string{"project_id", "project_name"},
+				Type:          schema.TypeInt,
+				Optional:      true,
+				Computed:      true,
+				Description:   "The ID of the project. Either 'project_id' or 'project_name' must be specified, unless the provider sets a default project.",
+				ConflictsWith: []string{"project_name"},
 			},
 			"project_name": {
-				Type:         schema.TypeString,
-				Optional:     true,
-				Description:  "The name of the project. Either 'project_id' or 'project_name' must be specified.",
-				ExactlyOneOf: []string{"project_id", "project_name"},
+				Type:          schema.TypeString,
+				Optional:      true,
+				Description:   "The name of the project. Either 'project_id' or 'project_name' must be specified, unless the provider sets a default project.",
+				ConflictsWith: []string{"project_id"},
 			},
 			"region_id": {
-				Type:         schema.TypeInt,
-				Optional:     true,
-				Description:  "The uuid of the region. Either 'region_id' or 'region_name' must be specified.",
-				ExactlyOneOf: []string{"region_id", "region_name"},
+				Type:          schema.TypeInt,
+				Optional:      true,
+				Computed:      true,
+				Description:   "The ID of the region. Either 'region_id' or 'region_name' must be specified, unless the provider sets a default region.",
+				ConflictsWith: []string{"region_name"},
 			},
 			"region_name": {
-				Type:         schema.TypeString,
-				Optional:     true,
-				Description:  "The name of the region. Either 'region_id' or 'region_name' must be specified.",
-				ExactlyOneOf: []string{"region_id", "region_name"},
+				Type:          schema.TypeString,
+				Optional:      true,
+				Description:   "The name of the region. Either 'region_id' or 'region_name' must be specified, unless the provider sets a default region.",
+				ConflictsWith: []string{"region_id"},
 			},
 			"name": {
 				Type:        schema.TypeString,
@@ -149,6 +155,14 @@ func resourceSubnet() *schema.Resource {
 					Type: schema.TypeString,
 				},
 			},
+			"metadata_all": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Description: "A map containing all metadata of the resource, including the provider `default_metadata`.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
 			"metadata_read_only": {
 				Type:        schema.TypeList,
 				Computed:    true,
@@ -184,9 +198,8 @@ func resourceSubnetCreate(ctx context.Context, d *schema.ResourceData, m interfa
 	log.Println("[DEBUG] Start Subnet creating")
 	var diags diag.Diagnostics
 	config := m.(*Config)
-	provider := config.Provider
 
-	client, err := CreateClient(provider, d, SubnetPoint, VersionPointV1)
+	client, err := CreateClient(config, d, SubnetPoint, VersionPointV1)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -237,7 +250,7 @@ func resourceSubnetCreate(ctx context.Context, d *schema.ResourceData, m interfa
 		createOpts.GatewayIP = &gw
 	}
 
-	if metadataRaw, ok := d.GetOk("metadata_map"); ok {
+	if metadataRaw := metadataWithDefaults(config, d.Get("metadata_map")); len(metadataRaw) > 0 {
 		meta, err := utils.MapInterfaceToMapString(metadataRaw)
 		if err != nil {
 			return diag.FromErr(err)
@@ -253,7 +266,7 @@ func resourceSubnetCreate(ctx context.Context, d *schema.ResourceData, m interfa
 
 	taskID := results.Tasks[0]
 	log.Printf("[DEBUG] Task id (%s)", taskID)
-	subnetID, err := tasks.WaitTaskAndReturnResult(client, taskID, true, SubnetCreatingTimeout, func(task tasks.TaskID) (interface{}, error) {
+	subnetID, err := waitTaskAndReturnResult(ctx, client, taskID, d.Timeout(schema.TimeoutCreate), func(task tasks.TaskID) (interface{}, error) {
 		taskInfo, err := tasks.Get(client, string(task)).Extract()
 		if err != nil {
 			return nil, fmt.Errorf("cannot get task with ID: %s. Error: %w", task, err)
@@ -283,17 +296,19 @@ func resourceSubnetRead(_ context.Context, d *schema.ResourceData, m interface{}
 	log.Printf("[DEBUG] Start subnet reading%s", d.State())
 	var diags diag.Diagnostics
 	config := m.(*Config)
-	provider := config.Provider
 	subnetID := d.Id()
 	log.Printf("[DEBUG] Subnet id = %s", subnetID)
 
-	client, err := CreateClient(provider, d, SubnetPoint, VersionPointV1)
+	client, err := CreateClient(config, d, SubnetPoint, VersionPointV1)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
 	subnet, err := subnets.Get(client, subnetID).Extract()
 	if err != nil {
+		if removeIfNotFound(d, "subnet", err) {
+			return nil
+		}
 		return diag.Errorf("cannot get subnet with ID: %s. Error: %s", subnetID, err)
 	}
 
@@ -330,7 +345,10 @@ func resourceSubnetRead(_ context.Context, d *schema.ResourceData, m interface{}
 
 	metadataMap, metadataReadOnly := PrepareMetadata(subnet.Metadata)
 
-	if err = d.Set("metadata_map", metadataMap); err != nil {
+	if err = d.Set("metadata_map", metadataWithoutDefaults(config, metadataMap, d.Get("metadata_map"))); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("metadata_all", metadataMap); err != nil {
 		return diag.FromErr(err)
 	}
 
@@ -348,8 +366,7 @@ func resourceSubnetUpdate(ctx context.Context, d *schema.ResourceData, m interfa
 	subnetID := d.Id()
 	log.Printf("[DEBUG] Subnet id = %s", subnetID)
 	config := m.(*Config)
-	provider := config.Provider
-	client, err := CreateClient(provider, d, SubnetPoint, VersionPointV1)
+	client, err := CreateClient(config, d, SubnetPoint, VersionPointV1)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -396,8 +413,8 @@ func resourceSubnetUpdate(ctx context.Context, d *schema.ResourceData, m interfa
 		return diag.FromErr(err)
 	}
 
-	if d.HasChange("metadata_map") {
-		_, nmd := d.GetChange("metadata_map")
+	if d.HasChanges("metadata_map", "metadata_all") {
+		nmd := metadataWithDefaults(config, d.Get("metadata_map"))
 		meta, err := utils.MapInterfaceToMapString(nmd)
 		if err != nil {
 			return diag.Errorf("metadata wrong fmt. Error: %s", err)
@@ -414,15 +431,14 @@ func resourceSubnetUpdate(ctx context.Context, d *schema.ResourceData, m interfa
 	return resourceSubnetRead(ctx, d, m)
 }
 
-func resourceSubnetDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+func resourceSubnetDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	log.Println("[DEBUG] Start subnet deleting")
 	var diags diag.Diagnostics
 	config := m.(*Config)
-	provider := config.Provider
 	subnetID := d.Id()
 	log.Printf("[DEBUG] Subnet id = %s", subnetID)
 
-	client, err := CreateClient(provider, d, SubnetPoint, VersionPointV1)
+	client, err := CreateClient(config, d, SubnetPoint, VersionPointV1)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -433,13 +449,12 @@ func resourceSubnetDelete(_ context.Context, d *schema.ResourceData, m interface
 	}
 	taskID := results.Tasks[0]
 	log.Printf("[DEBUG] Task id (%s)", taskID)
-	_, err = tasks.WaitTaskAndReturnResult(client, taskID, true, SubnetDeleting, func(task tasks.TaskID) (interface{}, error) {
+	_, err = waitTaskAndReturnResult(ctx, client, taskID, d.Timeout(schema.TimeoutDelete), func(task tasks.TaskID) (interface{}, error) {
 		_, err := subnets.Get(client, subnetID).Extract()
 		if err == nil {
 			return nil, fmt.Errorf("cannot delete subnet with ID: %s", subnetID)
 		}
-		var errDefault404 edgecloud.Default404Error
-		if errors.As(err, &errDefault404) {
+		if isNotFound(err) {
 			return nil, nil
 		}
 		return nil, fmt.Errorf("extracting Subnet resource error: %w", err)
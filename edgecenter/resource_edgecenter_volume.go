@@ -2,7 +2,6 @@ package edgecenter
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"log"
 	"time"
@@ -30,11 +29,17 @@ func resourceVolume() *schema.Resource {
 		ReadContext:   resourceVolumeRead,
 		UpdateContext: resourceVolumeUpdate,
 		DeleteContext: resourceVolumeDelete,
+		CustomizeDiff: customizeDiffMetadataAll,
 		Description: `A volume is a detachable block storage device akin to a USB hard drive or SSD, but located remotely in the cloud.
 Volumes can be attached to a virtual machine and manipulated like a physical hard drive.`,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(time.Duration(VolumeCreatingTimeout) * time.Second),
+			Update: schema.DefaultTimeout(time.Duration(volumeExtending) * time.Second),
+			Delete: schema.DefaultTimeout(time.Duration(volumeDeleting) * time.Second),
+		},
 		Importer: &schema.ResourceImporter{
 			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-				projectID, regionID, volumeID, err := ImportStringParser(d.Id())
+				projectID, regionID, volumeID, err := ImportStringParser(meta.(*Config), d.Id())
 				if err != nil {
 					return nil, err
 				}
@@ -43,9 +48,8 @@ Volumes can be attached to a virtual machine and manipulated like a physical har
 				d.SetId(volumeID)
 
 				config := meta.(*Config)
-				provider := config.Provider
 
-				client, err := CreateClient(provider, d, VolumesPoint, VersionPointV1)
+				client, err := CreateClient(config, d, VolumesPoint, VersionPointV1)
 				if err != nil {
 					return nil, err
 				}
@@ -62,28 +66,30 @@ Volumes can be attached to a virtual machine and manipulated like a physical har
 
 		Schema: map[string]*schema.Schema{
 			"project_id": {
-				Type:         schema.TypeInt,
-				Optional:     true,
-				Description:  "The uuid of the project. Either 'project_id' or 'project_name' must be specified.",
-				ExactlyOneOf: []string{"project_id", "project_name"},
+				Type:          schema.TypeInt,
+				Optional:      true,
+				Computed:      true,
+				Description:   "The ID of the project. Either 'project_id' or 'project_name' must be specified, unless the provider sets a default project.",
+				ConflictsWith: []string{"project_name"},
 			},
 			"project_name": {
-				Type:         schema.TypeString,
-				Optional:     true,
-				Description:  "The name of the project. Either 'project_id' or 'project_name' must be specified.",
-				ExactlyOneOf: []string{"project_id", "project_name"},
+				Type:          schema.TypeString,
+				Optional:      true,
+				Description:   "The name of the project. Either 'project_id' or 'project_name' must be specified, unless the provider sets a default project.",
+				ConflictsWith: []string{"project_id"},
 			},
 			"region_id": {
-				Type:         schema.TypeInt,
-				Optional:     true,
-				Description:  "The uuid of the region. Either 'region_id' or 'region_name' must be specified.",
-				ExactlyOneOf: []string{"region_id", "region_name"},
+				Type:          schema.TypeInt,
+				Optional:      true,
+				Computed:      true,
+				Description:   "The ID of the region. Either 'region_id' or 'region_name' must be specified, unless the provider sets a default region.",
+				ConflictsWith: []string{"region_name"},
 			},
 			"region_name": {
-				Type:         schema.TypeString,
-				Optional:     true,
-				Description:  "The name of the region. Either 'region_id' or 'region_name' must be specified.",
-				ExactlyOneOf: []string{"region_id", "region_name"},
+				Type:          schema.TypeString,
+				Optional:      true,
+				Description:   "The name of the region. Either 'region_id' or 'region_name' must be specified, unless the provider sets a default region.",
+				ConflictsWith: []string{"region_id"},
 			},
 			"name": {
 				Type:        schema.TypeString,
@@ -127,6 +133,14 @@ Volumes can be attached to a virtual machine and manipulated like a physical har
 					Type: schema.TypeString,
 				},
 			},
+			"metadata_all": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Description: "A map containing all metadata of the resource, including the provider `default_metadata`.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
 			"metadata_read_only": {
 				Type:        schema.TypeList,
 				Computed:    true,
@@ -156,14 +170,13 @@ func resourceVolumeCreate(ctx context.Context, d *schema.ResourceData, m interfa
 	log.Println("[DEBUG] Start volume creating")
 	var diags diag.Diagnostics
 	config := m.(*Config)
-	provider := config.Provider
 
-	client, err := CreateClient(provider, d, VolumesPoint, VersionPointV1)
+	client, err := CreateClient(config, d, VolumesPoint, VersionPointV1)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	opts, err := getVolumeData(d)
+	opts, err := getVolumeData(config, d)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -174,7 +187,7 @@ func resourceVolumeCreate(ctx context.Context, d *schema.ResourceData, m interfa
 
 	taskID := results.Tasks[0]
 	log.Printf("[DEBUG] Task id (%s)", taskID)
-	VolumeID, err := tasks.WaitTaskAndReturnResult(client, taskID, true, VolumeCreatingTimeout, func(task tasks.TaskID) (interface{}, error) {
+	VolumeID, err := waitTaskAndReturnResult(ctx, client, taskID, d.Timeout(schema.TimeoutCreate), func(task tasks.TaskID) (interface{}, error) {
 		taskInfo, err := tasks.Get(client, string(task)).Extract()
 		if err != nil {
 			return nil, fmt.Errorf("cannot get task with ID: %s. Error: %w", task, err)
@@ -204,17 +217,19 @@ func resourceVolumeRead(_ context.Context, d *schema.ResourceData, m interface{}
 	log.Printf("[DEBUG] Start volume reading%s", d.State())
 	var diags diag.Diagnostics
 	config := m.(*Config)
-	provider := config.Provider
 	volumeID := d.Id()
 	log.Printf("[DEBUG] Volume id = %s", volumeID)
 
-	client, err := CreateClient(provider, d, VolumesPoint, VersionPointV1)
+	client, err := CreateClient(config, d, VolumesPoint, VersionPointV1)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
 	volume, err := volumes.Get(client, volumeID).Extract()
 	if err != nil {
+		if removeIfNotFound(d, "volume", err) {
+			return nil
+		}
 		return diag.Errorf("cannot get volume with ID: %s. Error: %s", volumeID, err)
 	}
 
@@ -226,7 +241,10 @@ func resourceVolumeRead(_ context.Context, d *schema.ResourceData, m interface{}
 
 	metadataMap, metadataReadOnly := PrepareMetadata(volume.Metadata)
 
-	if err = d.Set("metadata_map", metadataMap); err != nil {
+	if err = d.Set("metadata_map", metadataWithoutDefaults(config, metadataMap, d.Get("metadata_map"))); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("metadata_all", metadataMap); err != nil {
 		return diag.FromErr(err)
 	}
 
@@ -247,8 +265,7 @@ func resourceVolumeUpdate(ctx context.Context, d *schema.ResourceData, m interfa
 	volumeID := d.Id()
 	log.Printf("[DEBUG] Volume id = %s", volumeID)
 	config := m.(*Config)
-	provider := config.Provider
-	client, err := CreateClient(provider, d, VolumesPoint, VersionPointV1)
+	client, err := CreateClient(config, d, VolumesPoint, VersionPointV1)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -271,7 +288,7 @@ func resourceVolumeUpdate(ctx context.Context, d *schema.ResourceData, m interfa
 		newSize := newValue.(int)
 		if newSize != 0 {
 			if volume.Size < newSize {
-				err = ExtendVolume(client, volumeID, newSize)
+				err = ExtendVolume(ctx, client, volumeID, newSize, d.Timeout(schema.TimeoutUpdate))
 				if err != nil {
 					return diag.FromErr(err)
 				}
@@ -297,10 +314,10 @@ func resourceVolumeUpdate(ctx context.Context, d *schema.ResourceData, m interfa
 		}
 	}
 
-	if d.HasChange("metadata_map") {
-		_, nmd := d.GetChange("metadata_map")
+	if d.HasChanges("metadata_map", "metadata_all") {
+		nmd := metadataWithDefaults(config, d.Get("metadata_map"))
 
-		meta, err := utils.MapInterfaceToMapString(nmd.(map[string]interface{}))
+		meta, err := utils.MapInterfaceToMapString(nmd)
 		if err != nil {
 			return diag.Errorf("cannot get metadata. Error: %s", err)
 		}
@@ -317,15 +334,14 @@ func resourceVolumeUpdate(ctx context.Context, d *schema.ResourceData, m interfa
 	return resourceVolumeRead(ctx, d, m)
 }
 
-func resourceVolumeDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+func resourceVolumeDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	log.Println("[DEBUG] Start volume deleting")
 	var diags diag.Diagnostics
 	config := m.(*Config)
-	provider := config.Provider
 	volumeID := d.Id()
 	log.Printf("[DEBUG] Volume id = %s", volumeID)
 
-	client, err := CreateClient(provider, d, VolumesPoint, VersionPointV1)
+	client, err := CreateClient(config, d, VolumesPoint, VersionPointV1)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -339,13 +355,12 @@ func resourceVolumeDelete(_ context.Context, d *schema.ResourceData, m interface
 	}
 	taskID := results.Tasks[0]
 	log.Printf("[DEBUG] Task id (%s)", taskID)
-	_, err = tasks.WaitTaskAndReturnResult(client, taskID, true, volumeDeleting, func(task tasks.TaskID) (interface{}, error) {
+	_, err = waitTaskAndReturnResult(ctx, client, taskID, d.Timeout(schema.TimeoutDelete), func(task tasks.TaskID) (interface{}, error) {
 		_, err := volumes.Get(client, volumeID).Extract()
 		if err == nil {
 			return nil, fmt.Errorf("cannot delete volume with ID: %s", volumeID)
 		}
-		var errDefault404 edgecloud.Default404Error
-		if errors.As(err, &errDefault404) {
+		if isNotFound(err) {
 			return nil, nil
 		}
 		return nil, fmt.Errorf("extracting Volume resource error: %w", err)
@@ -360,7 +375,7 @@ func resourceVolumeDelete(_ context.Context, d *schema.ResourceData, m interface
 	return diags
 }
 
-func getVolumeData(d *schema.ResourceData) (*volumes.CreateOpts, error) {
+func getVolumeData(config *Config, d *schema.ResourceData) (*volumes.CreateOpts, error) {
 	volumeData := volumes.CreateOpts{}
 	volumeData.Source = volumes.NewVolume
 	volumeData.Name = d.Get("name").(string)
@@ -390,7 +405,7 @@ func getVolumeData(d *schema.ResourceData) (*volumes.CreateOpts, error) {
 		volumeData.TypeName = *modifiedTypeName
 	}
 
-	if metadataRaw, ok := d.GetOk("metadata_map"); ok {
+	if metadataRaw := metadataWithDefaults(config, d.Get("metadata_map")); len(metadataRaw) > 0 {
 		meta, err := utils.MapInterfaceToMapString(metadataRaw)
 		if err != nil {
 			return nil, fmt.Errorf("volume metadata error: %w", err)
@@ -402,7 +417,8 @@ func getVolumeData(d *schema.ResourceData) (*volumes.CreateOpts, error) {
 	return &volumeData, nil
 }
 
-func ExtendVolume(client *edgecloud.ServiceClient, volumeID string, newSize int) error {
+// ExtendVolume resizes the volume to newSize gigabytes, waiting for the task up to timeout.
+func ExtendVolume(ctx context.Context, client *edgecloud.ServiceClient, volumeID string, newSize int, timeout time.Duration) error {
 	opts := volumes.SizePropertyOperationOpts{
 		Size: newSize,
 	}
@@ -413,7 +429,7 @@ func ExtendVolume(client *edgecloud.ServiceClient, volumeID string, newSize int)
 
 	taskID := results.Tasks[0]
 	log.Printf("[DEBUG] Task id (%s)", taskID)
-	_, err = tasks.WaitTaskAndReturnResult(client, taskID, true, volumeExtending, func(task tasks.TaskID) (interface{}, error) {
+	_, err = waitTaskAndReturnResult(ctx, client, taskID, timeout, func(task tasks.TaskID) (interface{}, error) {
 		_, err := volumes.Get(client, volumeID).Extract()
 		if err != nil {
 			return nil, fmt.Errorf("cannot get volume with ID: %s. Error: %w", volumeID, err)
@@ -0,0 +1,258 @@
+package edgecenter
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	edgecloud "github.com/Edge-Center/edgecentercloud-go"
+	"github.com/Edge-Center/edgecentercloud-go/edgecenter/volume/v1/volumes"
+)
+
+const (
+	volumeAttachmentTimeout int = 1200
+
+	volumeAttached = "attached"
+	volumeDetached = "detached"
+)
+
+func resourceVolumeAttachment() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceVolumeAttachmentCreate,
+		ReadContext:   resourceVolumeAttachmentRead,
+		DeleteContext: resourceVolumeAttachmentDelete,
+		Description: `Attaches a volume to an instance. Volumes attached this way are ignored by the volume set of edgecenter_instance,
+so the volume and the instance may be managed separately, e.g. by different modules.`,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(time.Duration(volumeAttachmentTimeout) * time.Second),
+			Delete: schema.DefaultTimeout(time.Duration(volumeAttachmentTimeout) * time.Second),
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				projectID, regionID, instanceID, volumeID, err := ImportStringParserExtended(meta.(*Config), d.Id())
+				if err != nil {
+					return nil, err
+				}
+				d.Set("project_id", projectID)
+				d.Set("region_id", regionID)
+				d.Set("instance_id", instanceID)
+				d.Set("volume_id", volumeID)
+				d.SetId(volumeID)
+
+				return []*schema.ResourceData{d}, nil
+			},
+		},
+
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:          schema.TypeInt,
+				Optional:      true,
+				Computed:      true,
+				ForceNew:      true,
+				Description:   "The ID of the project. Either 'project_id' or 'project_name' must be specified, unless the provider sets a default project.",
+				ConflictsWith: []string{"project_name"},
+			},
+			"project_name": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				Description:   "The name of the project. Either 'project_id' or 'project_name' must be specified, unless the provider sets a default project.",
+				ConflictsWith: []string{"project_id"},
+			},
+			"region_id": {
+				Type:          schema.TypeInt,
+				Optional:      true,
+				Computed:      true,
+				ForceNew:      true,
+				Description:   "The ID of the region. Either 'region_id' or 'region_name' must be specified, unless the provider sets a default region.",
+				ConflictsWith: []string{"region_name"},
+			},
+			"region_name": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				Description:   "The name of the region. Either 'region_id' or 'region_name' must be specified, unless the provider sets a default region.",
+				ConflictsWith: []string{"region_id"},
+			},
+			"instance_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The uuid of the instance to attach the volume to.",
+			},
+			"volume_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The uuid of the volume to attach.",
+			},
+			"attachment_tag": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The tag of the attachment, which the guest OS may use to identify the device.",
+			},
+			"device": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The device name of the volume in the instance, for example '/dev/vdb'.",
+			},
+		},
+	}
+}
+
+// volumeAttachOpts extends volumes.InstanceOperationOpts with the attachment tag.
+type volumeAttachOpts struct {
+	InstanceID    string `json:"instance_id" required:"true" validate:"required,uuid4"`
+	AttachmentTag string `json:"attachment_tag,omitempty"`
+}
+
+// ToVolumeInstanceOperationMap builds a request body.
+func (opts volumeAttachOpts) ToVolumeInstanceOperationMap() (map[string]interface{}, error) {
+	if err := edgecloud.TranslateValidationError(edgecloud.Validate.Struct(opts)); err != nil {
+		return nil, err
+	}
+	return edgecloud.BuildRequestBody(opts, "")
+}
+
+func resourceVolumeAttachmentCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Println("[DEBUG] Start volume attachment creating")
+	config := m.(*Config)
+
+	client, err := CreateClient(config, d, VolumesPoint, VersionPointV1)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	volumeID := d.Get("volume_id").(string)
+	instanceID := d.Get("instance_id").(string)
+	opts := volumeAttachOpts{
+		InstanceID:    instanceID,
+		AttachmentTag: d.Get("attachment_tag").(string),
+	}
+	log.Printf("[DEBUG] Volume %s attach options: %+v", volumeID, opts)
+	if _, err := volumes.Attach(client, volumeID, opts).Extract(); err != nil {
+		return diag.Errorf("cannot attach volume %s to instance %s. Error: %s", volumeID, instanceID, err)
+	}
+
+	if err := waitVolumeAttachment(ctx, client, volumeID, instanceID, volumeAttached, d.Timeout(schema.TimeoutCreate)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(volumeID)
+	log.Printf("[DEBUG] Finish volume attachment creating (%s)", volumeID)
+
+	return resourceVolumeAttachmentRead(ctx, d, m)
+}
+
+func resourceVolumeAttachmentRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Println("[DEBUG] Start volume attachment reading")
+	var diags diag.Diagnostics
+	config := m.(*Config)
+
+	client, err := CreateClient(config, d, VolumesPoint, VersionPointV1)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	volume, err := volumes.Get(client, d.Id()).Extract()
+	if err != nil {
+		if removeIfNotFound(d, "volume attachment", err) {
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	attachment, ok := findVolumeAttachment(volume, d.Get("instance_id").(string))
+	if !ok {
+		log.Printf("[WARN] Removing volume attachment %s because the volume is not attached to the instance anymore", d.Id())
+		d.SetId("")
+		return nil
+	}
+	d.Set("volume_id", volume.ID)
+	d.Set("device", attachment.Device)
+
+	fields := []string{"project_id", "region_id"}
+	revertState(d, &fields)
+
+	log.Println("[DEBUG] Finish volume attachment reading")
+
+	return diags
+}
+
+func resourceVolumeAttachmentDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Println("[DEBUG] Start volume attachment deleting")
+	var diags diag.Diagnostics
+	config := m.(*Config)
+
+	client, err := CreateClient(config, d, VolumesPoint, VersionPointV1)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	volumeID := d.Id()
+	instanceID := d.Get("instance_id").(string)
+	opts := volumes.InstanceOperationOpts{InstanceID: instanceID}
+	if _, err := volumes.Detach(client, volumeID, opts).Extract(); err != nil {
+		if !isNotFound(err) {
+			return diag.Errorf("cannot detach volume %s from instance %s. Error: %s", volumeID, instanceID, err)
+		}
+	} else if err := waitVolumeAttachment(ctx, client, volumeID, instanceID, volumeDetached, d.Timeout(schema.TimeoutDelete)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	log.Printf("[DEBUG] Finish of volume attachment deleting")
+
+	return diags
+}
+
+// findVolumeAttachment returns the attachment of the volume to the instance.
+func findVolumeAttachment(volume *volumes.Volume, instanceID string) (volumes.Attachment, bool) {
+	for _, attachment := range volume.Attachments {
+		if attachment.ServerID == instanceID {
+			return attachment, true
+		}
+	}
+
+	return volumes.Attachment{}, false
+}
+
+// waitVolumeAttachment waits until the volume is attached to the instance or detached from it.
+// A volume which doesn't exist anymore counts as detached.
+func waitVolumeAttachment(ctx context.Context, client *edgecloud.ServiceClient, volumeID, instanceID, target string, timeout time.Duration) error {
+	pending := volumeDetached
+	if target == volumeDetached {
+		pending = volumeAttached
+	}
+
+	stateConf := &retry.StateChangeConf{
+		Pending: []string{pending},
+		Target:  []string{target},
+		Refresh: func() (interface{}, string, error) {
+			volume, err := volumes.Get(client, volumeID).Extract()
+			if err != nil {
+				if isNotFound(err) {
+					return volumeID, volumeDetached, nil
+				}
+				return nil, "", err
+			}
+			if _, ok := findVolumeAttachment(volume, instanceID); ok {
+				return volume, volumeAttached, nil
+			}
+			return volume, volumeDetached, nil
+		},
+		Timeout:    timeout,
+		MinTimeout: 3 * time.Second,
+	}
+	if _, err := stateConf.WaitForStateContext(ctx); err != nil {
+		return fmt.Errorf("error waiting for volume %s to become %s: %w", volumeID, target, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,128 @@
+//go:build unit
+
+package edgecenter_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"github.com/Edge-Center/terraform-provider-edgecenter/edgecenter/test/fakeapi"
+)
+
+func TestUnitCloudInitConfigDataSource(t *testing.T) {
+	t.Parallel()
+	server := fakeapi.NewServer(t)
+
+	parts := `
+  part {
+    content_type = "text/cloud-config"
+    filename     = "init.cfg"
+    content      = <<-EOT
+      #cloud-config
+      packages:
+        - nginx
+    EOT
+  }
+
+  part {
+    content_type = "text/x-shellscript"
+    merge_type   = "list(append)+dict(recurse_array)+str()"
+    content      = "#!/bin/sh\necho hello"
+  }
+`
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: unitProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: server.ProviderConfig() + `
+data "edgecenter_cloudinit_config" "plain" {
+` + parts + `
+}
+
+data "edgecenter_cloudinit_config" "gzip" {
+  gzip          = true
+  base64_encode = true
+` + parts + `
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrWith("data.edgecenter_cloudinit_config.plain", "rendered", func(rendered string) error {
+						for _, expected := range []string{
+							`Content-Type: multipart/mixed; boundary="MIMEBOUNDARY"`,
+							`Content-Disposition: attachment; filename="init.cfg"`,
+							"Content-Type: text/cloud-config",
+							"X-Merge-Type: list(append)+dict(recurse_array)+str()",
+							"#!/bin/sh\necho hello",
+							"--MIMEBOUNDARY--",
+						} {
+							if !strings.Contains(rendered, expected) {
+								return fmt.Errorf("%q not found in the document", expected)
+							}
+						}
+						return nil
+					}),
+					resource.TestCheckResourceAttrSet("data.edgecenter_cloudinit_config.plain", "size"),
+					resource.TestCheckResourceAttrWith("data.edgecenter_cloudinit_config.gzip", "rendered", func(rendered string) error {
+						compressed, err := base64.StdEncoding.DecodeString(rendered)
+						if err != nil {
+							return err
+						}
+						r, err := gzip.NewReader(bytes.NewReader(compressed))
+						if err != nil {
+							return err
+						}
+						document, err := io.ReadAll(r)
+						if err != nil {
+							return err
+						}
+						if !strings.Contains(string(document), "packages:\n  - nginx") {
+							return fmt.Errorf("unexpected document %q", document)
+						}
+						return nil
+					}),
+				),
+			},
+			{
+				Config: server.ProviderConfig() + `
+data "edgecenter_cloudinit_config" "unit" {
+  part {
+    content_type = "text/cloud-config"
+    content      = "packages: [nginx"
+  }
+}
+`,
+				ExpectError: regexp.MustCompile(`part 0 is not a valid cloud-config`),
+			},
+			{
+				Config: server.ProviderConfig() + `
+data "edgecenter_cloudinit_config" "unit" {
+  part {
+    content = "` + strings.Repeat("x", 50000) + `"
+  }
+}
+`,
+				ExpectError: regexp.MustCompile(`exceeds the user data limit of 65535 bytes`),
+			},
+			{
+				Config: server.ProviderConfig() + `
+data "edgecenter_cloudinit_config" "unit" {
+  gzip = true
+  part {
+    content = "echo"
+  }
+}
+`,
+				ExpectError: regexp.MustCompile(`set base64_encode to true`),
+			},
+		},
+	})
+}
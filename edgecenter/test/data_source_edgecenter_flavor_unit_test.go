@@ -0,0 +1,128 @@
+//go:build unit
+
+package edgecenter_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"github.com/Edge-Center/terraform-provider-edgecenter/edgecenter/test/fakeapi"
+)
+
+func TestUnitFlavorDataSources(t *testing.T) {
+	t.Parallel()
+	server := fakeapi.NewServer(t)
+	server.AddFlavor(map[string]interface{}{
+		"flavor_id": "g1-standard-2-4", "flavor_name": "g1-standard-2-4", "vcpus": 2, "ram": 4096,
+		"hardware_description": map[string]string{"cpu": "Intel Xeon", "ram": "4GB"},
+		"price_per_hour":       0.05, "price_per_month": 36, "currency_code": "USD", "price_status": "show",
+	}, false)
+	server.AddFlavor(map[string]interface{}{
+		"flavor_id": "g1-standard-4-8", "flavor_name": "g1-standard-4-8", "vcpus": 4, "ram": 8192,
+		"hardware_description": map[string]string{"cpu": "Intel Xeon", "ram": "8GB"},
+		"price_per_hour":       0.1, "price_per_month": 72, "currency_code": "USD", "price_status": "show",
+	}, false)
+	server.AddFlavor(map[string]interface{}{
+		"flavor_id": "g1-gpu-8-32", "flavor_name": "g1-gpu-8-32", "vcpus": 8, "ram": 32768,
+		"hardware_description": map[string]string{"cpu": "AMD EPYC", "gpu": "1x NVIDIA A100"},
+		"price_per_hour":       2.5, "price_per_month": 1800, "currency_code": "USD", "price_status": "show",
+	}, false)
+	// a flavor with a hidden price is smaller than the priced ones but ordered after them
+	server.AddFlavor(map[string]interface{}{
+		"flavor_id": "g1-standard-1-2", "flavor_name": "g1-standard-1-2", "vcpus": 1, "ram": 2048,
+		"hardware_description": map[string]string{"cpu": "Intel Xeon", "ram": "2GB"},
+		"price_status":         "hide",
+	}, false)
+	server.AddFlavor(map[string]interface{}{
+		"flavor_id": "g0-standard-4-8", "flavor_name": "g0-standard-4-8", "vcpus": 4, "ram": 8192, "disabled": true,
+		"hardware_description": map[string]string{"cpu": "Intel Xeon"},
+	}, false)
+	server.AddFlavor(map[string]interface{}{
+		"flavor_id": "bm1-infrastructure-small", "flavor_name": "bm1-infrastructure-small", "vcpus": 16, "ram": 65536,
+		"hardware_description": map[string]string{"cpu": "2x Intel Xeon", "disk": "2x480GB SSD"},
+	}, true)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: unitProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: server.ProviderConfig() + `
+data "edgecenter_flavors" "vm" {
+  ` + unitCloudScope() + `
+  min_vcpus = 4
+}
+
+data "edgecenter_flavors" "all" {
+  ` + unitCloudScope() + `
+  include_disabled = true
+}
+
+data "edgecenter_flavors" "priced" {
+  ` + unitCloudScope() + `
+  has_gpu        = false
+  include_prices = true
+}
+
+data "edgecenter_flavors" "bm" {
+  ` + unitCloudScope() + `
+  is_baremetal = true
+}
+
+data "edgecenter_flavor" "cheapest" {
+  ` + unitCloudScope() + `
+  has_gpu        = false
+  include_prices = true
+  hardware_description = {
+    cpu = "xeon"
+  }
+}
+
+data "edgecenter_flavor" "gpu" {
+  ` + unitCloudScope() + `
+  has_gpu        = true
+  max_ram        = 32768
+  include_prices = true
+}
+
+data "edgecenter_flavor" "unpriced" {
+  ` + unitCloudScope() + `
+  flavor_name = "G1-STANDARD-4-8"
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.edgecenter_flavors.vm", "flavors.#", "2"),
+					resource.TestCheckResourceAttr("data.edgecenter_flavors.vm", "flavors.0.flavor_id", "g1-standard-4-8"),
+					resource.TestCheckResourceAttr("data.edgecenter_flavors.vm", "flavors.1.flavor_id", "g1-gpu-8-32"),
+					resource.TestCheckResourceAttr("data.edgecenter_flavors.vm", "flavors.1.gpu", "true"),
+					resource.TestCheckResourceAttr("data.edgecenter_flavors.all", "flavors.#", "5"),
+					resource.TestCheckResourceAttr("data.edgecenter_flavors.priced", "flavors.#", "3"),
+					resource.TestCheckResourceAttr("data.edgecenter_flavors.priced", "flavors.0.flavor_id", "g1-standard-2-4"),
+					resource.TestCheckResourceAttr("data.edgecenter_flavors.priced", "flavors.1.flavor_id", "g1-standard-4-8"),
+					resource.TestCheckResourceAttr("data.edgecenter_flavors.priced", "flavors.2.flavor_id", "g1-standard-1-2"),
+					resource.TestCheckResourceAttr("data.edgecenter_flavors.priced", "flavors.2.price_status", "hide"),
+					resource.TestCheckResourceAttr("data.edgecenter_flavors.bm", "flavors.#", "1"),
+					resource.TestCheckResourceAttr("data.edgecenter_flavors.bm", "flavors.0.hardware.disk", "2x480GB SSD"),
+					resource.TestCheckResourceAttr("data.edgecenter_flavor.cheapest", "id", "g1-standard-2-4"),
+					resource.TestCheckResourceAttr("data.edgecenter_flavor.cheapest", "price_per_hour", "0.05"),
+					resource.TestCheckResourceAttr("data.edgecenter_flavor.cheapest", "currency_code", "USD"),
+					resource.TestCheckResourceAttr("data.edgecenter_flavor.gpu", "flavor_id", "g1-gpu-8-32"),
+					resource.TestCheckResourceAttr("data.edgecenter_flavor.gpu", "price_per_month", "1800"),
+					resource.TestCheckResourceAttr("data.edgecenter_flavor.unpriced", "flavor_id", "g1-standard-4-8"),
+					resource.TestCheckResourceAttr("data.edgecenter_flavor.unpriced", "price_per_hour", "0"),
+					resource.TestCheckResourceAttr("data.edgecenter_flavor.unpriced", "disabled", "false"),
+				),
+			},
+			{
+				Config: server.ProviderConfig() + `
+data "edgecenter_flavor" "unit" {
+  ` + unitCloudScope() + `
+  flavor_id = "g0-standard-4-8"
+}
+`,
+				ExpectError: regexp.MustCompile(`flavor matching the filters not found`),
+			},
+		},
+	})
+}
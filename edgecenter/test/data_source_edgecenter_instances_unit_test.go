@@ -0,0 +1,129 @@
+//go:build unit
+
+package edgecenter_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"github.com/Edge-Center/terraform-provider-edgecenter/edgecenter/test/fakeapi"
+)
+
+func TestUnitInstancesDataSource(t *testing.T) {
+	t.Parallel()
+	server := fakeapi.NewServer(t)
+
+	instance := func(name, flavor, env string) string {
+		return fmt.Sprintf(`
+resource "edgecenter_instance" "%[2]s" {
+  %[1]s
+  name      = "%[2]s"
+  flavor_id = "%[3]s"
+
+  volume {
+    source     = "image"
+    image_id   = "b5b4d65d-945f-4b98-ab6f-332319c724ef"
+    size       = 5
+    boot_index = 0
+  }
+
+  interface {
+    type = "external"
+  }
+
+  metadata_map = {
+    env = "%[4]s"
+  }
+}
+`, unitCloudScope(), name, flavor, env)
+	}
+	resources := server.ProviderConfig() +
+		instance("web-1", "g1-standard-1-2", "prod") +
+		instance("web-2", "g1-standard-2-4", "prod") +
+		instance("db-1", "g1-standard-2-4", "dev") + fmt.Sprintf(`
+resource "edgecenter_servergroup" "unit" {
+  %[1]s
+  name   = "unit-group"
+  policy = "anti-affinity"
+}
+
+resource "edgecenter_servergroup_member" "unit" {
+  %[1]s
+  servergroup_id = edgecenter_servergroup.unit.id
+  instance_id    = edgecenter_instance.web-2.id
+}
+`, unitCloudScope())
+	dataSources := fmt.Sprintf(`
+data "edgecenter_instances" "all" {
+  %[1]s
+  depends_on = [edgecenter_servergroup_member.unit]
+}
+
+data "edgecenter_instances" "web" {
+  %[1]s
+  name_regex = "^web-"
+  depends_on = [edgecenter_servergroup_member.unit]
+}
+
+data "edgecenter_instances" "prod_large" {
+  %[1]s
+  metadata_kv = {
+    env = "prod"
+  }
+  flavor_id  = "g1-standard-2-4"
+  depends_on = [edgecenter_servergroup_member.unit]
+}
+
+data "edgecenter_instances" "grouped" {
+  %[1]s
+  servergroup_id = edgecenter_servergroup.unit.id
+  changes_since  = "2023-10-01T03:00:00+03:00"
+  depends_on     = [edgecenter_servergroup_member.unit]
+}
+`, unitCloudScope())
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: unitProviderFactories(),
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testUnitCheckNoCloudObjects(server, "instances"),
+			testUnitCheckNoCloudObjects(server, "servergroups"),
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: resources,
+			},
+			{
+				Config: resources + dataSources,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.edgecenter_instances.all", "instances.#", "3"),
+					// ordered by name
+					resource.TestCheckResourceAttr("data.edgecenter_instances.all", "instances.0.name", "db-1"),
+					resource.TestCheckResourceAttr("data.edgecenter_instances.all", "instances.1.name", "web-1"),
+					resource.TestCheckResourceAttr("data.edgecenter_instances.all", "instances.2.name", "web-2"),
+					resource.TestCheckResourceAttr("data.edgecenter_instances.web", "instances.#", "2"),
+					resource.TestCheckResourceAttr("data.edgecenter_instances.prod_large", "instances.#", "1"),
+					resource.TestCheckResourceAttrPair("data.edgecenter_instances.prod_large", "instances.0.id", "edgecenter_instance.web-2", "id"),
+					resource.TestCheckResourceAttr("data.edgecenter_instances.prod_large", "instances.0.flavor_id", "g1-standard-2-4"),
+					resource.TestCheckResourceAttr("data.edgecenter_instances.prod_large", "instances.0.status", "ACTIVE"),
+					resource.TestCheckResourceAttr("data.edgecenter_instances.prod_large", "instances.0.metadata_map.env", "prod"),
+					resource.TestCheckResourceAttr("data.edgecenter_instances.prod_large", "instances.0.volume.#", "1"),
+					resource.TestCheckResourceAttrSet("data.edgecenter_instances.prod_large", "instances.0.addresses.0.net.0.addr"),
+					resource.TestCheckResourceAttr("data.edgecenter_instances.grouped", "instances.#", "1"),
+					resource.TestCheckResourceAttrPair("data.edgecenter_instances.grouped", "instances.0.id", "edgecenter_instance.web-2", "id"),
+				),
+			},
+			{
+				Config: resources + fmt.Sprintf(`
+data "edgecenter_instances" "invalid" {
+  %s
+  changes_since = "yesterday"
+}
+`, unitCloudScope()),
+				ExpectError: regexp.MustCompile(`changes_since`),
+			},
+		},
+	})
+}
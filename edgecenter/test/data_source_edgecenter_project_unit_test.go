@@ -0,0 +1,87 @@
+//go:build unit
+
+package edgecenter_test
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"github.com/Edge-Center/terraform-provider-edgecenter/edgecenter/test/fakeapi"
+)
+
+func TestUnitProjectAndRegionDataSource(t *testing.T) {
+	t.Parallel()
+	server := fakeapi.NewServer(t)
+
+	config := server.ProviderConfig() + `
+data "edgecenter_project" "unit" {
+  name = "` + fakeapi.DefaultProjectName + `"
+}
+
+data "edgecenter_region" "unit" {
+  name = "` + fakeapi.DefaultRegionName + `"
+}
+`
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: unitProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.edgecenter_project.unit", "id", strconv.Itoa(fakeapi.DefaultProjectID)),
+					resource.TestCheckResourceAttr("data.edgecenter_region.unit", "id", strconv.Itoa(fakeapi.DefaultRegionID)),
+				),
+			},
+		},
+	})
+}
+
+func TestUnitProjectAndRegionNameResolution(t *testing.T) {
+	t.Parallel()
+	server := fakeapi.NewServer(t)
+	otherProjectID := server.AddProject("Other")
+	server.AddProject("twin")
+	server.AddProject("twin")
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: unitProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: server.ProviderConfig() + `
+data "edgecenter_project" "unit" {
+  name = "OTHER"
+}
+
+data "edgecenter_region" "unit" {
+  name = "` + strings.ToLower(fakeapi.DefaultRegionName) + `"
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.edgecenter_project.unit", "id", strconv.Itoa(otherProjectID)),
+					resource.TestCheckResourceAttr("data.edgecenter_region.unit", "id", strconv.Itoa(fakeapi.DefaultRegionID)),
+				),
+			},
+			{
+				Config: server.ProviderConfig() + `
+data "edgecenter_project" "unit" {
+  name = "twin"
+}
+`,
+				ExpectError: regexp.MustCompile(`project name twin is ambiguous`),
+			},
+			{
+				Config: server.ProviderConfig() + `
+data "edgecenter_project" "unit" {
+  name = "missing"
+}
+`,
+				ExpectError: regexp.MustCompile(`project with name missing not found`),
+			},
+		},
+	})
+}
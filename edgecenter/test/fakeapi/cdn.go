@@ -0,0 +1,90 @@
+package fakeapi
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// serveCDN is a generic REST store: POST on a collection creates an object with a numeric ID,
+// GET/PUT/PATCH/DELETE on {collection}/{id} read, replace, merge or remove it.
+// It is enough for resources, rules, origin groups and SSL certificates, which all share this layout.
+func (s *Server) serveCDN(w http.ResponseWriter, r *http.Request, path string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if obj, ok := s.cdn[path]; ok {
+		switch r.Method {
+		case http.MethodGet:
+			writeJSON(w, http.StatusOK, copyObject(obj))
+		case http.MethodPut, http.MethodPatch:
+			body, err := decodeBody(r)
+			if err != nil {
+				writeError(w, http.StatusBadRequest, err.Error())
+				return
+			}
+			if r.Method == http.MethodPut {
+				body["id"] = obj["id"]
+				s.cdn[path] = body
+			} else {
+				for k, v := range body {
+					obj[k] = v
+				}
+			}
+			writeJSON(w, http.StatusOK, copyObject(s.cdn[path]))
+		case http.MethodDelete:
+			for key := range s.cdn {
+				if key == path || strings.HasPrefix(key, path+"/") {
+					delete(s.cdn, key)
+				}
+			}
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			writeError(w, http.StatusMethodNotAllowed, r.Method)
+		}
+		return
+	}
+
+	switch r.Method {
+	case http.MethodPost:
+		body, err := decodeBody(r)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		id := s.nextID()
+		body["id"] = id
+		if _, ok := body["status"]; !ok {
+			body["status"] = "active"
+		}
+		s.cdn[fmt.Sprintf("%s/%d", path, id)] = body
+		writeJSON(w, http.StatusCreated, copyObject(body))
+	case http.MethodGet:
+		if _, err := strconv.Atoi(path[strings.LastIndex(path, "/")+1:]); err == nil {
+			writeError(w, http.StatusNotFound, fmt.Sprintf("%s not found", path))
+			return
+		}
+		writeJSON(w, http.StatusOK, s.cdnCollection(path))
+	default:
+		writeError(w, http.StatusNotFound, fmt.Sprintf("%s not found", path))
+	}
+}
+
+func (s *Server) cdnCollection(path string) []map[string]interface{} {
+	keys := make([]string, 0)
+	for key := range s.cdn {
+		if strings.HasPrefix(key, path+"/") && !strings.Contains(strings.TrimPrefix(key, path+"/"), "/") {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	items := make([]map[string]interface{}, len(keys))
+	for i, key := range keys {
+		items[i] = copyObject(s.cdn[key])
+	}
+
+	return items
+}
@@ -0,0 +1,621 @@
+package fakeapi
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	kindInstances = "instances"
+	kindVolumes   = "volumes"
+	kindNetworks  = "networks"
+	kindPorts     = "ports"
+	kindImages    = "images"
+	kindFlavors   = "flavors"
+	kindBMFlavors = "bmflavors"
+
+	kindServerGroups = "servergroups"
+
+	// kindBMInstances is the endpoint for baremetal servers, which are stored and read as instances.
+	kindBMInstances = "bminstances"
+)
+
+// serveCloud handles /cloud/{version}/{kind}[/{project}/{region}[/{id}[/{action}...]]].
+func (s *Server) serveCloud(w http.ResponseWriter, r *http.Request, parts []string) {
+	if len(parts) < 2 {
+		writeError(w, http.StatusNotFound, "unknown cloud endpoint")
+		return
+	}
+
+	for i, part := range parts {
+		if part == "tasks" && i+1 < len(parts) {
+			s.serveTask(w, r, parts[len(parts)-1])
+			return
+		}
+	}
+
+	kind := parts[1]
+	if kind == kindBMInstances {
+		kind = kindInstances
+	}
+	switch kind {
+	case kindKeypairs:
+		s.serveKeypairs(w, r, parts[2:])
+		return
+	case "projects":
+		s.serveStaticList(w, r, s.projects, parts[2:])
+		return
+	case "regions":
+		s.serveStaticList(w, r, s.regions, parts[2:])
+		return
+	}
+
+	if len(parts) < 4 {
+		writeError(w, http.StatusNotFound, "project and region are required")
+		return
+	}
+	projectID, errP := strconv.Atoi(parts[2])
+	regionID, errR := strconv.Atoi(parts[3])
+	if errP != nil || errR != nil {
+		writeError(w, http.StatusBadRequest, "invalid project or region")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(parts) == 4 {
+		switch r.Method {
+		case http.MethodGet:
+			items := s.listObjects(kind, projectID, regionID)
+			if kind == kindInstances {
+				items = filterInstances(items, r.URL.Query())
+			}
+			if (kind == kindFlavors || kind == kindBMFlavors) && r.URL.Query().Get("include_prices") != "true" {
+				for _, item := range items {
+					for _, field := range []string{"price_per_hour", "price_per_month", "currency_code", "price_status"} {
+						delete(item, field)
+					}
+				}
+			}
+			writeJSON(w, http.StatusOK, listResult(items))
+		case http.MethodPost:
+			body, err := decodeBody(r)
+			if err != nil {
+				writeError(w, http.StatusBadRequest, err.Error())
+				return
+			}
+			created, err := s.createObjects(kind, projectID, regionID, body)
+			if err != nil {
+				writeError(w, http.StatusBadRequest, err.Error())
+				return
+			}
+			if kind == kindServerGroups {
+				// server groups are created synchronously
+				writeJSON(w, http.StatusOK, s.renderObject(kind, s.cloud[kind][created[kind][0]]))
+				return
+			}
+			writeJSON(w, http.StatusOK, s.newTaskWithResources(created, nil))
+		default:
+			writeError(w, http.StatusMethodNotAllowed, r.Method)
+		}
+		return
+	}
+
+	id := parts[4]
+	obj, ok := s.cloud[kind][id]
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("%s %s not found", kind, id))
+		return
+	}
+
+	if len(parts) > 5 {
+		s.serveCloudAction(w, r, kind, id, obj, parts[5:])
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, s.renderObject(kind, obj))
+		if kind == kindInstances {
+			// a reboot in progress is over after it was seen
+			delete(obj, "task_state")
+		}
+	case http.MethodPatch, http.MethodPut:
+		body, err := decodeBody(r)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		if name, ok := body["name"]; ok {
+			obj[nameField(kind)] = name
+		}
+		if kind == kindImages {
+			for _, field := range imageUpdateFields {
+				if v, ok := body[field]; ok {
+					obj[field] = v
+				}
+			}
+		}
+		writeJSON(w, http.StatusOK, s.renderObject(kind, obj))
+	case http.MethodDelete:
+		s.deleteObject(kind, id)
+		if kind == kindInstances {
+			// volumes listed in the query are deleted together with the instance
+			for _, volumeID := range strings.Split(r.URL.Query().Get("volumes"), ",") {
+				delete(s.cloud[kindVolumes], volumeID)
+			}
+		}
+		writeJSON(w, http.StatusOK, s.newTask(kind))
+	default:
+		writeError(w, http.StatusMethodNotAllowed, r.Method)
+	}
+}
+
+func (s *Server) serveStaticList(w http.ResponseWriter, r *http.Request, items []map[string]interface{}, rest []string) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, r.Method)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(rest) == 0 {
+		writeJSON(w, http.StatusOK, listResult(items))
+		return
+	}
+	for _, item := range items {
+		if fmt.Sprint(item["id"]) == rest[0] {
+			writeJSON(w, http.StatusOK, copyObject(item))
+			return
+		}
+	}
+	writeError(w, http.StatusNotFound, fmt.Sprintf("%s not found", rest[0]))
+}
+
+func (s *Server) serveTask(w http.ResponseWriter, r *http.Request, taskID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	task, ok := s.tasks[taskID]
+	if !ok || r.Method != http.MethodGet {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("task %s not found", taskID))
+		return
+	}
+	writeJSON(w, http.StatusOK, copyObject(task))
+}
+
+// newTask registers a task in the configured state and returns the task list response body.
+func (s *Server) newTask(kind string, createdIDs ...string) map[string]interface{} {
+	return s.newTaskWithData(kind, nil, createdIDs...)
+}
+
+func (s *Server) newTaskWithData(kind string, data map[string]interface{}, createdIDs ...string) map[string]interface{} {
+	return s.newTaskWithResources(map[string][]string{kind: createdIDs}, data)
+}
+
+// newTaskWithResources registers a task which created the given IDs by kind.
+func (s *Server) newTaskWithResources(createdIDs map[string][]string, data map[string]interface{}) map[string]interface{} {
+	taskID := s.nextUUID()
+	created := map[string]interface{}{}
+	for kind, ids := range createdIDs {
+		if len(ids) > 0 {
+			created[kind] = ids
+		}
+	}
+	if data == nil {
+		data = map[string]interface{}{}
+	}
+	s.tasks[taskID] = map[string]interface{}{
+		"id":                taskID,
+		"state":             s.taskState,
+		"task_type":         "fake",
+		"project_id":        DefaultProjectID,
+		"client_id":         DefaultClientID,
+		"created_resources": created,
+		"data":              data,
+	}
+
+	return map[string]interface{}{"tasks": []string{taskID}}
+}
+
+func (s *Server) objects(kind string) map[string]map[string]interface{} {
+	if _, ok := s.cloud[kind]; !ok {
+		s.cloud[kind] = make(map[string]map[string]interface{})
+	}
+
+	return s.cloud[kind]
+}
+
+func (s *Server) listObjects(kind string, projectID, regionID int) []map[string]interface{} {
+	ids := make([]string, 0, len(s.cloud[kind]))
+	for id := range s.cloud[kind] {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	items := make([]map[string]interface{}, 0, len(ids))
+	for _, id := range ids {
+		obj := s.cloud[kind][id]
+		if obj["project_id"] == projectID && obj["region_id"] == regionID {
+			items = append(items, s.renderObject(kind, obj))
+		}
+	}
+
+	return items
+}
+
+// createObjects creates the requested objects and returns their IDs by kind.
+func (s *Server) createObjects(kind string, projectID, regionID int, body map[string]interface{}) (map[string][]string, error) {
+	switch kind {
+	case kindInstances:
+		ids, volumeIDs, err := s.createInstances(projectID, regionID, body)
+		if err != nil {
+			return nil, err
+		}
+		return map[string][]string{kindInstances: ids, kindVolumes: volumeIDs}, nil
+	case kindVolumes:
+		id := s.createVolume(projectID, regionID, body)
+		return map[string][]string{kind: {id}}, nil
+	case kindNetworks:
+		id := s.createNetwork(projectID, regionID, body)
+		return map[string][]string{kind: {id}}, nil
+	case kindServerGroups:
+		policy, _ := body["policy"].(string)
+		if policy != "affinity" && policy != "anti-affinity" {
+			return nil, fmt.Errorf("invalid policy %q", policy)
+		}
+		id := s.nextUUID()
+		s.objects(kind)[id] = map[string]interface{}{
+			"servergroup_id": id,
+			"name":           body["name"],
+			"policy":         policy,
+			"project_id":     projectID,
+			"region_id":      regionID,
+		}
+		return map[string][]string{kind: {id}}, nil
+	case kindImages, "downloadimage":
+		id, err := s.createImage(projectID, regionID, body)
+		if err != nil {
+			return nil, err
+		}
+		return map[string][]string{kindImages: {id}}, nil
+	}
+
+	id := s.nextUUID()
+	obj := copyObject(body)
+	obj["id"] = id
+	obj["project_id"] = projectID
+	obj["region_id"] = regionID
+	delete(obj, "metadata")
+	obj["metadata"] = metadataList(body["metadata"])
+	s.objects(kind)[id] = obj
+
+	return map[string][]string{kind: {id}}, nil
+}
+
+func (s *Server) createVolume(projectID, regionID int, body map[string]interface{}) string {
+	id := s.nextUUID()
+	volumeType, _ := body["type_name"].(string)
+	if volumeType == "" {
+		volumeType = "standard"
+	}
+	imageID, _ := body["image_id"].(string)
+	s.objects(kindVolumes)[id] = map[string]interface{}{
+		"id":                    id,
+		"name":                  body["name"],
+		"size":                  body["size"],
+		"volume_type":           volumeType,
+		"status":                "available",
+		"bootable":              imageID != "",
+		"project_id":            projectID,
+		"region_id":             regionID,
+		"attachments":           []interface{}{},
+		"metadata":              metadataList(body["metadata"]),
+		"snapshot_id":           body["snapshot_id"],
+		"volume_image_metadata": map[string]interface{}{"image_id": imageID},
+	}
+
+	return id
+}
+
+func (s *Server) createNetwork(projectID, regionID int, body map[string]interface{}) string {
+	id := s.nextUUID()
+	networkType, _ := body["type"].(string)
+	if networkType == "" {
+		networkType = "vxlan"
+	}
+	s.objects(kindNetworks)[id] = map[string]interface{}{
+		"id":         id,
+		"name":       body["name"],
+		"mtu":        1450,
+		"type":       networkType,
+		"external":   false,
+		"default":    false,
+		"shared":     false,
+		"subnets":    []interface{}{},
+		"project_id": projectID,
+		"region_id":  regionID,
+		"metadata":   metadataList(body["metadata"]),
+	}
+
+	return id
+}
+
+// createImage downloads an image from body["url"] or creates it from body["volume_id"].
+func (s *Server) createImage(projectID, regionID int, body map[string]interface{}) (string, error) {
+	minDisk, diskFormat := 0, "qcow2"
+	if volumeID, ok := body["volume_id"].(string); ok {
+		volume, ok := s.objects(kindVolumes)[volumeID]
+		if !ok {
+			return "", fmt.Errorf("volume %s not found", volumeID)
+		}
+		size, _ := volume["size"].(float64)
+		minDisk, diskFormat = int(size), "raw"
+	}
+
+	id := s.nextUUID()
+	image := map[string]interface{}{
+		"id":                id,
+		"name":              body["name"],
+		"status":            "active",
+		"visibility":        "private",
+		"disk_format":       diskFormat,
+		"min_disk":          minDisk,
+		"min_ram":           0,
+		"size":              minDisk << 30,
+		"os_distro":         body["os_distro"],
+		"os_version":        body["os_version"],
+		"project_id":        projectID,
+		"region_id":         regionID,
+		"metadata_detailed": metadataList(body["metadata"]),
+	}
+	for _, field := range imageUpdateFields {
+		image[field] = body[field]
+	}
+	s.objects(kindImages)[id] = image
+
+	return id, nil
+}
+
+// imageUpdateFields are the image fields, besides the name, which may be changed.
+var imageUpdateFields = []string{"os_type", "ssh_key", "hw_machine_type", "hw_firmware_type", "is_baremetal"}
+
+func (s *Server) deleteObject(kind, id string) {
+	if kind == kindInstances {
+		for _, vol := range s.objects(kindVolumes) {
+			if vol["instance_id"] == id {
+				delete(vol, "instance_id")
+				vol["status"] = "available"
+			}
+		}
+		delete(s.interfaces, id)
+	}
+	delete(s.cloud[kind], id)
+}
+
+func (s *Server) renderObject(kind string, obj map[string]interface{}) map[string]interface{} {
+	out := copyObject(obj)
+	switch kind {
+	case kindInstances:
+		return s.renderInstance(out)
+	case kindVolumes:
+		return renderVolume(out)
+	case kindServerGroups:
+		return s.renderServerGroup(out)
+	}
+
+	return out
+}
+
+// renderVolume lists the attachment of an attached volume.
+func renderVolume(volume map[string]interface{}) map[string]interface{} {
+	attachments := make([]map[string]interface{}, 0, 1)
+	if instanceID, ok := volume["instance_id"].(string); ok {
+		attachments = append(attachments, map[string]interface{}{
+			"server_id":     instanceID,
+			"volume_id":     volume["id"],
+			"attachment_id": volume["id"],
+			"device":        "/dev/vdb",
+		})
+	}
+	volume["attachments"] = attachments
+
+	return volume
+}
+
+// renderServerGroup lists the instances put into the server group.
+func (s *Server) renderServerGroup(group map[string]interface{}) map[string]interface{} {
+	members := make([]map[string]interface{}, 0)
+	for _, instance := range s.objects(kindInstances) {
+		if instance["server_group_id"] == group["servergroup_id"] {
+			members = append(members, map[string]interface{}{
+				"instance_id":   instance["instance_id"],
+				"instance_name": instance["instance_name"],
+			})
+		}
+	}
+	sort.Slice(members, func(i, j int) bool {
+		return fmt.Sprint(members[i]["instance_id"]) < fmt.Sprint(members[j]["instance_id"])
+	})
+	group["instances"] = members
+
+	return group
+}
+
+// serveCloudAction handles sub-resources and actions such as /{id}/metadata or /{id}/extend.
+func (s *Server) serveCloudAction(w http.ResponseWriter, r *http.Request, kind, id string, obj map[string]interface{}, action []string) {
+	body, err := decodeBody(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	switch action[0] {
+	case "metadata", "metadata_item":
+		s.serveMetadata(w, r, kind, obj, action[1:], body)
+		return
+	}
+
+	if kind == kindInstances {
+		s.serveInstanceAction(w, r, id, obj, action, body)
+		return
+	}
+
+	switch action[0] {
+	case "extend":
+		obj["size"] = body["size"]
+		writeJSON(w, http.StatusOK, s.newTask(kind))
+	case "retype":
+		obj["volume_type"] = body["volume_type"]
+		writeJSON(w, http.StatusOK, s.renderObject(kind, obj))
+	case "attach":
+		if _, ok := s.objects(kindInstances)[fmt.Sprint(body["instance_id"])]; !ok {
+			writeError(w, http.StatusNotFound, fmt.Sprintf("instance %v not found", body["instance_id"]))
+			return
+		}
+		obj["instance_id"] = body["instance_id"]
+		obj["attachment_tag"] = body["attachment_tag"]
+		obj["status"] = "in-use"
+		writeJSON(w, http.StatusOK, s.renderObject(kind, obj))
+	case "detach":
+		delete(obj, "instance_id")
+		delete(obj, "attachment_tag")
+		obj["status"] = "available"
+		writeJSON(w, http.StatusOK, s.renderObject(kind, obj))
+	default:
+		writeError(w, http.StatusNotFound, fmt.Sprintf("unknown action %s", action[0]))
+	}
+}
+
+func (s *Server) serveMetadata(w http.ResponseWriter, r *http.Request, kind string, obj map[string]interface{}, rest []string, body map[string]interface{}) {
+	meta := metadataMap(obj, kind)
+
+	key := r.URL.Query().Get("key")
+	if len(rest) > 0 {
+		key = rest[0]
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		if key == "" {
+			writeJSON(w, http.StatusOK, listResult(metadataItems(meta)))
+			return
+		}
+		value, ok := meta[key]
+		if !ok {
+			writeError(w, http.StatusNotFound, fmt.Sprintf("metadata key %s not found", key))
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{"key": key, "value": value, "read_only": false})
+		return
+	case http.MethodPut:
+		meta = map[string]string{}
+		fallthrough
+	case http.MethodPost, http.MethodPatch:
+		for k, v := range metadataFromBody(body) {
+			meta[k] = v
+		}
+	case http.MethodDelete:
+		delete(meta, key)
+	}
+	setMetadata(obj, kind, meta)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func nameField(kind string) string {
+	if kind == kindInstances {
+		return "instance_name"
+	}
+	return "name"
+}
+
+// metadataFromBody accepts both {"k": "v"} and {"metadata": [{"key": "k", "value": "v"}]} payloads.
+func metadataFromBody(body map[string]interface{}) map[string]string {
+	result := make(map[string]string)
+	if items, ok := body["metadata"].([]interface{}); ok {
+		for _, item := range items {
+			if m, ok := item.(map[string]interface{}); ok {
+				result[fmt.Sprint(m["key"])] = fmt.Sprint(m["value"])
+			}
+		}
+		return result
+	}
+	for k, v := range body {
+		result[k] = fmt.Sprint(v)
+	}
+
+	return result
+}
+
+func metadataList(raw interface{}) []map[string]interface{} {
+	var meta map[string]string
+	switch v := raw.(type) {
+	case map[string]interface{}:
+		meta = metadataFromBody(v)
+	case []interface{}:
+		meta = metadataFromBody(map[string]interface{}{"metadata": v})
+	default:
+		meta = map[string]string{}
+	}
+
+	return metadataItems(meta)
+}
+
+func metadataItems(meta map[string]string) []map[string]interface{} {
+	keys := make([]string, 0, len(meta))
+	for k := range meta {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	items := make([]map[string]interface{}, 0, len(keys))
+	for _, k := range keys {
+		items = append(items, map[string]interface{}{"key": k, "value": meta[k], "read_only": false})
+	}
+
+	return items
+}
+
+func metadataMap(obj map[string]interface{}, kind string) map[string]string {
+	field := "metadata"
+	if kind == kindInstances || kind == kindImages {
+		field = "metadata_detailed"
+	}
+	meta := make(map[string]string)
+	switch items := obj[field].(type) {
+	case []map[string]interface{}:
+		for _, item := range items {
+			meta[fmt.Sprint(item["key"])] = fmt.Sprint(item["value"])
+		}
+	case []interface{}:
+		for _, item := range items {
+			if m, ok := item.(map[string]interface{}); ok {
+				meta[fmt.Sprint(m["key"])] = fmt.Sprint(m["value"])
+			}
+		}
+	}
+
+	return meta
+}
+
+func setMetadata(obj map[string]interface{}, kind string, meta map[string]string) {
+	if kind == kindInstances {
+		obj["metadata_detailed"] = metadataItems(meta)
+		plain := make(map[string]interface{}, len(meta))
+		for k, v := range meta {
+			plain[k] = v
+		}
+		obj["metadata"] = plain
+		return
+	}
+	if kind == kindImages {
+		obj["metadata_detailed"] = metadataItems(meta)
+		return
+	}
+	obj["metadata"] = metadataItems(meta)
+}
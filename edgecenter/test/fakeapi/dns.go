@@ -0,0 +1,152 @@
+package fakeapi
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+type dnsZone struct {
+	id     int
+	name   string
+	rrsets map[string]map[string]interface{}
+}
+
+// serveDNS handles /dns/v2/zones[/{zone}[/{name}/{type}]].
+func (s *Server) serveDNS(w http.ResponseWriter, r *http.Request, parts []string) {
+	if len(parts) < 2 || parts[0] != "v2" || parts[1] != "zones" {
+		writeError(w, http.StatusNotFound, "unknown dns endpoint")
+		return
+	}
+
+	body, err := decodeBody(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(parts) == 2 {
+		switch r.Method {
+		case http.MethodPost:
+			name, _ := body["name"].(string)
+			if _, ok := s.dnsZones[name]; ok {
+				writeError(w, http.StatusConflict, fmt.Sprintf("zone %s already exists", name))
+				return
+			}
+			zone := &dnsZone{id: s.nextID(), name: name, rrsets: make(map[string]map[string]interface{})}
+			s.dnsZones[name] = zone
+			writeJSON(w, http.StatusOK, map[string]interface{}{"id": zone.id})
+		case http.MethodGet:
+			names := make([]string, 0, len(s.dnsZones))
+			for name := range s.dnsZones {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			zones := make([]map[string]interface{}, len(names))
+			for i, name := range names {
+				zones[i] = s.renderZone(s.dnsZones[name])
+			}
+			writeJSON(w, http.StatusOK, map[string]interface{}{"zones": zones})
+		default:
+			writeError(w, http.StatusMethodNotAllowed, r.Method)
+		}
+		return
+	}
+
+	zone, ok := s.dnsZones[parts[2]]
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("zone %s not found", parts[2]))
+		return
+	}
+
+	if len(parts) == 3 {
+		switch r.Method {
+		case http.MethodGet:
+			writeJSON(w, http.StatusOK, s.renderZone(zone))
+		case http.MethodDelete:
+			delete(s.dnsZones, zone.name)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			writeError(w, http.StatusMethodNotAllowed, r.Method)
+		}
+		return
+	}
+
+	if len(parts) != 5 {
+		writeError(w, http.StatusNotFound, "unknown dns endpoint")
+		return
+	}
+	key := strings.ToLower(parts[3]) + "/" + strings.ToUpper(parts[4])
+	rrset, exists := zone.rrsets[key]
+
+	switch r.Method {
+	case http.MethodGet:
+		if !exists {
+			writeError(w, http.StatusNotFound, fmt.Sprintf("rrset %s not found", key))
+			return
+		}
+		writeJSON(w, http.StatusOK, copyObject(rrset))
+	case http.MethodPost:
+		if exists {
+			writeError(w, http.StatusConflict, fmt.Sprintf("rrset %s already exists", key))
+			return
+		}
+		zone.rrsets[key] = normalizeRRSet(body)
+		writeJSON(w, http.StatusOK, map[string]interface{}{})
+	case http.MethodPut:
+		if !exists {
+			writeError(w, http.StatusNotFound, fmt.Sprintf("rrset %s not found", key))
+			return
+		}
+		zone.rrsets[key] = normalizeRRSet(body)
+		writeJSON(w, http.StatusOK, map[string]interface{}{})
+	case http.MethodDelete:
+		if !exists {
+			writeError(w, http.StatusNotFound, fmt.Sprintf("rrset %s not found", key))
+			return
+		}
+		delete(zone.rrsets, key)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, r.Method)
+	}
+}
+
+func (s *Server) renderZone(zone *dnsZone) map[string]interface{} {
+	keys := make([]string, 0, len(zone.rrsets))
+	for key := range zone.rrsets {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	records := make([]map[string]interface{}, 0)
+	for _, key := range keys {
+		nameType := strings.SplitN(key, "/", 2)
+		for _, rr := range mapList(zone.rrsets[key]["resource_records"]) {
+			records = append(records, map[string]interface{}{
+				"name":          nameType[0],
+				"type":          nameType[1],
+				"ttl":           zone.rrsets[key]["ttl"],
+				"short_answers": []string{fmt.Sprint(rr["content"])},
+			})
+		}
+	}
+
+	return map[string]interface{}{"name": zone.name, "records": records}
+}
+
+func normalizeRRSet(body map[string]interface{}) map[string]interface{} {
+	rrset := copyObject(body)
+	if _, ok := rrset["filters"]; !ok || rrset["filters"] == nil {
+		rrset["filters"] = []interface{}{}
+	}
+	if _, ok := rrset["resource_records"]; !ok || rrset["resource_records"] == nil {
+		rrset["resource_records"] = []interface{}{}
+	}
+
+	return rrset
+}
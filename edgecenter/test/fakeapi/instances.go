@@ -0,0 +1,309 @@
+package fakeapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// createInstances creates one instance per requested name, attaching volumes and interfaces from the request.
+// It returns the IDs of the instances and of the volumes created for them.
+func (s *Server) createInstances(projectID, regionID int, body map[string]interface{}) ([]string, []string, error) {
+	names := stringList(body["names"])
+	if len(names) == 0 {
+		names = stringList(body["name_templates"])
+	}
+	if len(names) == 0 {
+		names = []string{"instance"}
+	}
+
+	flavorID, _ := body["flavor"].(string)
+	if flavorID == "" {
+		return nil, nil, fmt.Errorf("flavor is required")
+	}
+
+	ids := make([]string, 0, len(names))
+	var volumeIDs []string
+	for _, name := range names {
+		id := s.nextUUID()
+		instance := map[string]interface{}{
+			"instance_id":   id,
+			"instance_name": name,
+			"status":        "ACTIVE",
+			"vm_state":      "active",
+			"project_id":    projectID,
+			"region_id":     regionID,
+			"keypair_name":  body["keypair_name"],
+			"image_id":      body["image_id"],
+			"flavor": map[string]interface{}{
+				"flavor_id":   flavorID,
+				"flavor_name": flavorID,
+				"ram":         2048,
+				"vcpus":       1,
+			},
+		}
+		setMetadata(instance, kindInstances, metadataFromRaw(body["metadata"]))
+		s.objects(kindInstances)[id] = instance
+
+		for _, raw := range mapList(body["volumes"]) {
+			volumeID, _ := raw["volume_id"].(string)
+			created := volumeID == ""
+			if raw["source"] == "apptemplate" {
+				appTemplateID, _ := raw["apptemplate_id"].(string)
+				if appTemplateID == "" {
+					return nil, nil, fmt.Errorf("apptemplate_id is required for source apptemplate")
+				}
+				// the volume is created from the image of the template
+				raw["image_id"] = "image-of-" + appTemplateID
+			}
+			if created {
+				volumeID = s.createVolume(projectID, regionID, raw)
+				volumeIDs = append(volumeIDs, volumeID)
+			}
+			vol, ok := s.objects(kindVolumes)[volumeID]
+			if !ok {
+				return nil, nil, fmt.Errorf("volume %s not found", volumeID)
+			}
+			vol["instance_id"] = id
+			vol["status"] = "in-use"
+			vol["delete_on_termination"] = created || raw["delete_on_termination"] == true
+		}
+
+		for _, raw := range mapList(body["interfaces"]) {
+			s.attachInterface(id, raw)
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, volumeIDs, nil
+}
+
+// attachInterface creates a port for the instance and returns its ID.
+func (s *Server) attachInterface(instanceID string, iface map[string]interface{}) string {
+	portID := s.nextUUID()
+	networkID, _ := iface["network_id"].(string)
+	subnetID, _ := iface["subnet_id"].(string)
+	external := iface["type"] == "external"
+	if networkID == "" {
+		networkID = s.nextUUID()
+	}
+	if subnetID == "" {
+		subnetID = s.nextUUID()
+	}
+
+	sgs := make([]map[string]interface{}, 0)
+	for _, sg := range mapList(iface["security_groups"]) {
+		sgs = append(sgs, map[string]interface{}{"id": sg["id"], "name": sg["id"]})
+	}
+
+	items := s.interfaces[instanceID]
+	s.interfaces[instanceID] = append(items, map[string]interface{}{
+		"port_id":    portID,
+		"network_id": networkID,
+		"ip_assignments": []map[string]interface{}{{
+			"ip_address": fmt.Sprintf("192.168.%d.%d", len(items), s.seq%250+2),
+			"subnet_id":  subnetID,
+		}},
+		"network_details": map[string]interface{}{
+			"id":       networkID,
+			"name":     "network-" + networkID,
+			"external": external,
+			"mtu":      1450,
+		},
+		"security_groups": sgs,
+	})
+
+	return portID
+}
+
+func (s *Server) instanceInterfaces(instanceID string) []map[string]interface{} {
+	return s.interfaces[instanceID]
+}
+
+func (s *Server) renderInstance(instance map[string]interface{}) map[string]interface{} {
+	id := instance["instance_id"].(string)
+
+	volumes := make([]map[string]interface{}, 0)
+	for volumeID, vol := range s.objects(kindVolumes) {
+		if vol["instance_id"] == id {
+			volumes = append(volumes, map[string]interface{}{
+				"id":                    volumeID,
+				"delete_on_termination": vol["delete_on_termination"] == true,
+			})
+		}
+	}
+	instance["volumes"] = volumes
+
+	addresses := make(map[string][]map[string]interface{})
+	for _, iface := range s.instanceInterfaces(id) {
+		details := iface["network_details"].(map[string]interface{})
+		name := details["name"].(string)
+		for _, assignment := range iface["ip_assignments"].([]map[string]interface{}) {
+			addresses[name] = append(addresses[name], map[string]interface{}{
+				"addr": assignment["ip_address"],
+				"type": "fixed",
+			})
+		}
+	}
+	instance["addresses"] = addresses
+
+	return instance
+}
+
+func (s *Server) serveInstanceAction(w http.ResponseWriter, r *http.Request, id string, instance map[string]interface{}, action []string, body map[string]interface{}) {
+	switch action[0] {
+	case "interfaces":
+		writeJSON(w, http.StatusOK, listResult(copyList(s.instanceInterfaces(id))))
+	case "ports":
+		ports := make([]map[string]interface{}, 0)
+		for _, iface := range s.instanceInterfaces(id) {
+			ports = append(ports, map[string]interface{}{
+				"id":              iface["port_id"],
+				"security_groups": iface["security_groups"],
+			})
+		}
+		writeJSON(w, http.StatusOK, listResult(ports))
+	case "changeflavor":
+		flavor := instance["flavor"].(map[string]interface{})
+		flavor["flavor_id"] = body["flavor_id"]
+		flavor["flavor_name"] = body["flavor_id"]
+		writeJSON(w, http.StatusOK, s.newTask(kindInstances))
+	case "start", "resume":
+		instance["vm_state"] = "active"
+		writeJSON(w, http.StatusOK, s.renderInstance(copyObject(instance)))
+	case "reboot":
+		// the reboot shows up in task_state until the instance is read once
+		instance["task_state"] = "rebooting"
+		writeJSON(w, http.StatusOK, s.renderInstance(copyObject(instance)))
+	case "powercycle":
+		instance["task_state"] = "rebooting_hard"
+		writeJSON(w, http.StatusOK, s.renderInstance(copyObject(instance)))
+	case "rescue":
+		instance["vm_state"] = "rescued"
+		writeJSON(w, http.StatusOK, s.newTask(kindInstances))
+	case "unrescue":
+		instance["vm_state"] = "active"
+		writeJSON(w, http.StatusOK, s.newTask(kindInstances))
+	case "rebuild":
+		// the rebuild options of the SDK carry the image only
+		for field := range body {
+			if field != "image_id" {
+				writeError(w, http.StatusBadRequest, fmt.Sprintf("unexpected rebuild field %s", field))
+				return
+			}
+		}
+		instance["image_id"] = body["image_id"]
+		delete(instance, "apptemplate_id")
+		instance["vm_state"] = "active"
+		writeJSON(w, http.StatusOK, s.newTask(kindInstances))
+	case "stop":
+		instance["vm_state"] = "stopped"
+		writeJSON(w, http.StatusOK, s.renderInstance(copyObject(instance)))
+	case "suspend":
+		instance["vm_state"] = "suspended"
+		writeJSON(w, http.StatusOK, s.renderInstance(copyObject(instance)))
+	case "attach_interface":
+		portID := s.attachInterface(id, body)
+		writeJSON(w, http.StatusOK, s.newTask(kindPorts, portID))
+	case "detach_interface":
+		items := s.instanceInterfaces(id)
+		kept := make([]map[string]interface{}, 0, len(items))
+		for _, iface := range items {
+			if iface["port_id"] != body["port_id"] {
+				kept = append(kept, iface)
+			}
+		}
+		s.interfaces[id] = kept
+		writeJSON(w, http.StatusOK, s.newTask(kindPorts))
+	case "addsecuritygroup", "delsecuritygroup":
+		w.WriteHeader(http.StatusNoContent)
+	case "put_into_servergroup":
+		instance["server_group_id"] = body["servergroup_id"]
+		writeJSON(w, http.StatusOK, s.newTask(kindInstances))
+	case "remove_from_servergroup":
+		delete(instance, "server_group_id")
+		writeJSON(w, http.StatusOK, s.newTask(kindInstances))
+	default:
+		writeError(w, http.StatusNotFound, fmt.Sprintf("unknown instance action %s", action[0]))
+	}
+}
+
+// filterInstances applies the flavor, status and metadata filters of the instance list query.
+// The changes-since filter is accepted and ignored, as every fake instance is new.
+func filterInstances(items []map[string]interface{}, query url.Values) []map[string]interface{} {
+	var kv map[string]string
+	if raw := query.Get("metadata_kv"); raw != "" {
+		_ = json.Unmarshal([]byte(raw), &kv)
+	}
+
+	result := make([]map[string]interface{}, 0, len(items))
+	for _, item := range items {
+		meta, _ := item["metadata"].(map[string]interface{})
+		flavor, _ := item["flavor"].(map[string]interface{})
+		if v := query.Get("flavor_id"); v != "" && flavor["flavor_id"] != v {
+			continue
+		}
+		if v := query.Get("status"); v != "" && item["status"] != v {
+			continue
+		}
+		if k := query.Get("metadata_k"); k != "" {
+			if _, ok := meta[k]; !ok {
+				continue
+			}
+		}
+		matched := true
+		for k, v := range kv {
+			if meta[k] != v {
+				matched = false
+			}
+		}
+		if matched {
+			result = append(result, item)
+		}
+	}
+
+	return result
+}
+
+func metadataFromRaw(raw interface{}) map[string]string {
+	meta := make(map[string]string)
+	for _, item := range metadataList(raw) {
+		meta[fmt.Sprint(item["key"])] = fmt.Sprint(item["value"])
+	}
+
+	return meta
+}
+
+func stringList(raw interface{}) []string {
+	items, _ := raw.([]interface{})
+	result := make([]string, 0, len(items))
+	for _, item := range items {
+		if s, ok := item.(string); ok && s != "" {
+			result = append(result, s)
+		}
+	}
+
+	return result
+}
+
+func mapList(raw interface{}) []map[string]interface{} {
+	items, _ := raw.([]interface{})
+	result := make([]map[string]interface{}, 0, len(items))
+	for _, item := range items {
+		if m, ok := item.(map[string]interface{}); ok {
+			result = append(result, m)
+		}
+	}
+
+	return result
+}
+
+func copyList(items []map[string]interface{}) []map[string]interface{} {
+	result := make([]map[string]interface{}, len(items))
+	for i, item := range items {
+		result[i] = copyObject(item)
+	}
+
+	return result
+}
@@ -0,0 +1,343 @@
+// Package fakeapi provides an in-memory stand-in for the EdgeCenter cloud, CDN, DNS and storage APIs.
+// It is intended for provider unit tests that must run without network access or real credentials.
+package fakeapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultProjectID   = 1
+	DefaultProjectName = "default"
+	DefaultRegionID    = 1
+	DefaultRegionName  = "Luxembourg"
+	DefaultClientID    = 1
+
+	// PermanentToken is the only token accepted by the fake server.
+	PermanentToken = "fake-permanent-token" // nolint: gosec
+)
+
+// Server is an httptest based EdgeCenter API.
+// The cloud API is served under /cloud, the DNS API under /dns, the storage API under /storage
+// and the CDN API under /cdn, so the provider can be pointed at it with a single api_endpoint.
+type Server struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	seq      int
+	projects []map[string]interface{}
+	regions  []map[string]interface{}
+	cloud    map[string]map[string]map[string]interface{}
+	tasks    map[string]map[string]interface{}
+	// taskState is the state every new task is created in, FINISHED unless overridden.
+	taskState string
+	// throttled is the number of upcoming requests rejected with 429 Too Many Requests.
+	throttled int
+	// latency delays every response, so that concurrent requests overlap.
+	latency time.Duration
+	// inFlight and maxInFlight count the requests being served and the highest count seen.
+	inFlight    int
+	maxInFlight int
+	// interfaces holds instance ports keyed by instance ID.
+	interfaces map[string][]map[string]interface{}
+	cdn        map[string]map[string]interface{}
+	dnsZones   map[string]*dnsZone
+	storages   map[string]map[string]interface{}
+	buckets    map[string]map[string]map[string]interface{}
+	requests   []string
+}
+
+// NewServer starts a fake API server with a single project and region.
+// The server is closed automatically when the test finishes.
+func NewServer(t interface {
+	Helper()
+	Cleanup(func())
+},
+) *Server {
+	t.Helper()
+
+	s := &Server{
+		projects: []map[string]interface{}{{
+			"id":         DefaultProjectID,
+			"client_id":  DefaultClientID,
+			"name":       DefaultProjectName,
+			"is_default": true,
+			"state":      "ACTIVE",
+		}},
+		regions: []map[string]interface{}{{
+			"id":            DefaultRegionID,
+			"display_name":  DefaultRegionName,
+			"keystone_name": DefaultRegionName,
+			"state":         "ACTIVE",
+			"endpoint_type": "public",
+		}},
+		cloud:      make(map[string]map[string]map[string]interface{}),
+		tasks:      make(map[string]map[string]interface{}),
+		taskState:  "FINISHED",
+		interfaces: make(map[string][]map[string]interface{}),
+		cdn:        make(map[string]map[string]interface{}),
+		dnsZones:   make(map[string]*dnsZone),
+		storages:   make(map[string]map[string]interface{}),
+		buckets:    make(map[string]map[string]map[string]interface{}),
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	t.Cleanup(s.Close)
+
+	return s
+}
+
+// ProviderConfig returns a provider block that points every product client at the fake server.
+func (s *Server) ProviderConfig() string {
+	return fmt.Sprintf(`
+provider "edgecenter" {
+  permanent_api_token = "%s"
+  api_endpoint        = "%s"
+}
+`, PermanentToken, s.URL)
+}
+
+// AddProject registers an additional project and returns its ID.
+func (s *Server) AddProject(name string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id := len(s.projects) + 1
+	s.projects = append(s.projects, map[string]interface{}{
+		"id":        id,
+		"client_id": DefaultClientID,
+		"name":      name,
+		"state":     "ACTIVE",
+	})
+
+	return id
+}
+
+// AddRegion registers an additional region and returns its ID.
+func (s *Server) AddRegion(name string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id := len(s.regions) + 1
+	s.regions = append(s.regions, map[string]interface{}{
+		"id":            id,
+		"display_name":  name,
+		"keystone_name": name,
+		"state":         "ACTIVE",
+		"endpoint_type": "public",
+	})
+
+	return id
+}
+
+// AddFlavor registers a flavor of the default project and region. Baremetal flavors are listed separately.
+// The flavor must have a flavor_id; its prices are only listed when requested.
+func (s *Server) AddFlavor(flavor map[string]interface{}, baremetal bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	kind := kindFlavors
+	if baremetal {
+		kind = kindBMFlavors
+	}
+	obj := copyObject(flavor)
+	obj["project_id"] = DefaultProjectID
+	obj["region_id"] = DefaultRegionID
+	s.objects(kind)[obj["flavor_id"].(string)] = obj
+}
+
+// CloudObjects returns the number of cloud objects of the given kind, e.g. "volumes" or "instances".
+func (s *Server) CloudObjects(kind string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return len(s.cloud[kind])
+}
+
+// DeleteCloudObjects removes every cloud object of the given kind, as if it was deleted outside of Terraform.
+func (s *Server) DeleteCloudObjects(kind string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for id := range s.cloud[kind] {
+		s.deleteObject(kind, id)
+	}
+}
+
+// DeleteDNSZone removes a DNS zone with all of its records, as if it was deleted outside of Terraform.
+func (s *Server) DeleteDNSZone(name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.dnsZones, name)
+}
+
+// SetTaskState makes every task created from now on stay in the given state, e.g. "RUNNING" or "ERROR".
+func (s *Server) SetTaskState(state string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.taskState = state
+}
+
+// Throttle makes the next n requests fail with 429 Too Many Requests and "Retry-After: 0".
+func (s *Server) Throttle(n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.throttled = n
+}
+
+// SetLatency delays every response by d.
+func (s *Server) SetLatency(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.latency = d
+}
+
+// MaxConcurrentRequests returns the highest number of requests served at the same time so far.
+func (s *Server) MaxConcurrentRequests() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.maxInFlight
+}
+
+// Requests returns "METHOD /path" for every request handled so far.
+func (s *Server) Requests() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]string(nil), s.requests...)
+}
+
+// CountRequests returns how many requests were made with the given method and path prefix.
+func (s *Server) CountRequests(method, pathPrefix string) int {
+	var n int
+	for _, r := range s.Requests() {
+		if strings.HasPrefix(r, method+" "+pathPrefix) {
+			n++
+		}
+	}
+
+	return n
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	s.requests = append(s.requests, r.Method+" "+r.URL.Path)
+	throttled := s.throttled > 0
+	if throttled {
+		s.throttled--
+	}
+	s.inFlight++
+	if s.inFlight > s.maxInFlight {
+		s.maxInFlight = s.inFlight
+	}
+	latency := s.latency
+	s.mu.Unlock()
+
+	defer func() {
+		s.mu.Lock()
+		s.inFlight--
+		s.mu.Unlock()
+	}()
+	time.Sleep(latency)
+
+	if throttled {
+		w.Header().Set("Retry-After", "0")
+		writeError(w, http.StatusTooManyRequests, "too many requests")
+		return
+	}
+
+	if !authorized(r) {
+		writeError(w, http.StatusUnauthorized, "invalid token")
+		return
+	}
+
+	path := strings.Trim(r.URL.Path, "/")
+	switch {
+	case strings.HasPrefix(path, "cloud/"):
+		s.serveCloud(w, r, splitPath(strings.TrimPrefix(path, "cloud/")))
+	case strings.HasPrefix(path, "cdn/"):
+		s.serveCDN(w, r, path)
+	case strings.HasPrefix(path, "dns/"):
+		s.serveDNS(w, r, splitPath(strings.TrimPrefix(path, "dns/")))
+	case strings.HasPrefix(path, "storage/"):
+		s.serveStorage(w, r, splitPath(strings.TrimPrefix(path, "storage/")))
+	default:
+		writeError(w, http.StatusNotFound, fmt.Sprintf("unknown path %s", r.URL.Path))
+	}
+}
+
+func (s *Server) nextID() int {
+	s.seq++
+	return s.seq
+}
+
+func (s *Server) nextUUID() string {
+	return fmt.Sprintf("00000000-0000-4000-8000-%012d", s.nextID())
+}
+
+func authorized(r *http.Request) bool {
+	auth := r.Header.Get("Authorization")
+	return auth == "APIKey "+PermanentToken || auth == "Bearer "+PermanentToken
+}
+
+func splitPath(path string) []string {
+	if path == "" {
+		return nil
+	}
+	return strings.Split(strings.Trim(path, "/"), "/")
+}
+
+func decodeBody(r *http.Request) (map[string]interface{}, error) {
+	body := make(map[string]interface{})
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return body, nil
+	}
+	if err := json.Unmarshal(data, &body); err != nil {
+		return nil, fmt.Errorf("decode body: %w", err)
+	}
+
+	return body, nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if v != nil {
+		_ = json.NewEncoder(w).Encode(v)
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]interface{}{"message": message, "error": message})
+}
+
+// copyObject returns a deep copy of a JSON object so handlers never leak internal state.
+func copyObject(obj map[string]interface{}) map[string]interface{} {
+	data, _ := json.Marshal(obj)
+	var out map[string]interface{}
+	_ = json.Unmarshal(data, &out)
+
+	return out
+}
+
+func listResult(items []map[string]interface{}) map[string]interface{} {
+	results := make([]map[string]interface{}, len(items))
+	for i, item := range items {
+		results[i] = copyObject(item)
+	}
+
+	return map[string]interface{}{"count": len(results), "results": results}
+}
@@ -0,0 +1,177 @@
+package fakeapi
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+)
+
+// serveStorage handles /storage/resource/v3/storage[/{id}[/s3/bucket[s]/{name}]].
+func (s *Server) serveStorage(w http.ResponseWriter, r *http.Request, parts []string) {
+	if len(parts) < 3 || parts[0] != "resource" || parts[2] != "storage" {
+		writeError(w, http.StatusNotFound, "unknown storage endpoint")
+		return
+	}
+	parts = parts[3:]
+
+	body, err := decodeBody(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(parts) == 0 {
+		switch r.Method {
+		case http.MethodGet:
+			writeJSON(w, http.StatusOK, map[string]interface{}{"data": s.listStorages(r)})
+		case http.MethodPut, http.MethodPost:
+			id := s.nextID()
+			name := fmt.Sprintf("%d-%v", DefaultClientID, body["name"])
+			storage := map[string]interface{}{
+				"id":        id,
+				"client_id": DefaultClientID,
+				"name":      name,
+				"location":  body["location"],
+				"type":      body["type"],
+				"credentials": map[string]interface{}{
+					"keys": []interface{}{},
+					"s3": map[string]interface{}{
+						"access_key": fmt.Sprintf("access-%d", id),
+						"secret_key": fmt.Sprintf("secret-%d", id),
+					},
+				},
+			}
+			s.storages[strconv.Itoa(id)] = storage
+			writeJSON(w, http.StatusOK, copyObject(storage))
+		default:
+			writeError(w, http.StatusMethodNotAllowed, r.Method)
+		}
+		return
+	}
+
+	storageID := parts[0]
+	storage, ok := s.storages[storageID]
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("storage %s not found", storageID))
+		return
+	}
+
+	if len(parts) == 1 {
+		switch r.Method {
+		case http.MethodGet:
+			writeJSON(w, http.StatusOK, copyObject(storage))
+		case http.MethodDelete:
+			delete(s.storages, storageID)
+			delete(s.buckets, storageID)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			writeError(w, http.StatusMethodNotAllowed, r.Method)
+		}
+		return
+	}
+
+	if len(parts) >= 3 && parts[1] == "s3" && parts[2] == "buckets" && r.Method == http.MethodGet {
+		writeJSON(w, http.StatusOK, map[string]interface{}{"data": s.listBuckets(storageID)})
+		return
+	}
+
+	if len(parts) < 4 || parts[1] != "s3" || parts[2] != "bucket" {
+		writeError(w, http.StatusNotFound, "unknown storage endpoint")
+		return
+	}
+	name := parts[3]
+	if s.buckets[storageID] == nil {
+		s.buckets[storageID] = make(map[string]map[string]interface{})
+	}
+	bucket, exists := s.buckets[storageID][name]
+
+	if len(parts) == 4 {
+		switch r.Method {
+		case http.MethodPost:
+			if exists {
+				writeError(w, http.StatusBadRequest, fmt.Sprintf("bucket %s already exists", name))
+				return
+			}
+			s.buckets[storageID][name] = map[string]interface{}{"name": name}
+			w.WriteHeader(http.StatusNoContent)
+		case http.MethodDelete:
+			if !exists {
+				writeError(w, http.StatusNotFound, fmt.Sprintf("bucket %s not found", name))
+				return
+			}
+			delete(s.buckets[storageID], name)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			writeError(w, http.StatusMethodNotAllowed, r.Method)
+		}
+		return
+	}
+
+	if !exists {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("bucket %s not found", name))
+		return
+	}
+	switch parts[4] {
+	case "lifecycle":
+		if r.Method == http.MethodDelete {
+			delete(bucket, "lifecycle")
+		} else {
+			bucket["lifecycle"] = body["expiration_days"]
+		}
+		w.WriteHeader(http.StatusNoContent)
+	case "cors":
+		if r.Method == http.MethodGet {
+			writeJSON(w, http.StatusOK, map[string]interface{}{"data": fmt.Sprint(bucket["cors"])})
+			return
+		}
+		bucket["cors"] = body["allowedOrigins"]
+		w.WriteHeader(http.StatusNoContent)
+	case "policy":
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusNotFound, "unknown storage endpoint")
+	}
+}
+
+func (s *Server) listStorages(r *http.Request) []map[string]interface{} {
+	query := r.URL.Query()
+	ids := make([]string, 0, len(s.storages))
+	for id := range s.storages {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	result := make([]map[string]interface{}, 0)
+	for _, id := range ids {
+		storage := s.storages[id]
+		if qID := query.Get("id"); qID != "" && qID != id {
+			continue
+		}
+		if qName := query.Get("name"); qName != "" && qName != storage["name"] &&
+			fmt.Sprintf("%d-%s", DefaultClientID, qName) != storage["name"] {
+			continue
+		}
+		result = append(result, copyObject(storage))
+	}
+
+	return result
+}
+
+func (s *Server) listBuckets(storageID string) []map[string]interface{} {
+	names := make([]string, 0, len(s.buckets[storageID]))
+	for name := range s.buckets[storageID] {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	result := make([]map[string]interface{}, len(names))
+	for i, name := range names {
+		result[i] = copyObject(s.buckets[storageID][name])
+	}
+
+	return result
+}
@@ -12,9 +12,7 @@ import (
 	"strings"
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 
 	dnssdk "github.com/Edge-Center/edgecenter-dns-sdk-go"
 	storageSDK "github.com/Edge-Center/edgecenter-storage-sdk-go"
@@ -187,6 +185,7 @@ func objectInfo(resourceType string) string {
 }
 
 func createTestClient(provider *edgecloud.ProviderClient, endpoint, version string) (*edgecloud.ServiceClient, error) {
+	config := &edgecenter.Config{Provider: provider}
 	projectID := 0
 	var err error
 	if strProjectID, exists := os.LookupEnv("TEST_PROJECT_ID"); exists {
@@ -195,7 +194,7 @@ func createTestClient(provider *edgecloud.ProviderClient, endpoint, version stri
 			return nil, err
 		}
 	} else {
-		projectID, err = edgecenter.GetProject(provider, 0, os.Getenv("TEST_PROJECT_NAME"))
+		projectID, err = edgecenter.GetProject(config, 0, os.Getenv("TEST_PROJECT_NAME"))
 		if err != nil {
 			return nil, err
 		}
@@ -207,7 +206,7 @@ func createTestClient(provider *edgecloud.ProviderClient, endpoint, version stri
 			return nil, err
 		}
 	} else {
-		regionID, err = edgecenter.GetProject(provider, 0, os.Getenv("TEST_REGION_NAME"))
+		regionID, err = edgecenter.GetRegion(config, 0, os.Getenv("TEST_REGION_NAME"))
 		if err != nil {
 			return nil, err
 		}
@@ -272,21 +271,6 @@ func createTestConfig() (*edgecenter.Config, error) {
 	return &config, nil
 }
 
-func testAccCheckResourceExists(resourceName string) resource.TestCheckFunc {
-	return func(s *terraform.State) error {
-		// retrieve the resource by name from state
-		rs, ok := s.RootModule().Resources[resourceName]
-		if !ok {
-			return fmt.Errorf("not found: %s", resourceName)
-		}
-
-		if rs.Primary.ID == "" {
-			return fmt.Errorf("widget ID is not set")
-		}
-		return nil
-	}
-}
-
 var (
 	testAccProvider  *schema.Provider
 	testAccProviders map[string]func() (*schema.Provider, error)
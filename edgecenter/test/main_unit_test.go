@@ -0,0 +1,41 @@
+//go:build unit && !(cloud_data_source || cloud_resource || dns || storage || cdn)
+
+package edgecenter_test
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"testing"
+)
+
+// terraformPathEnv is read by resource.UnitTest to find the Terraform CLI.
+const terraformPathEnv = "TF_ACC_TERRAFORM_PATH"
+
+// TestMain stops the unit tests early when no Terraform CLI is available locally,
+// as resource.UnitTest would otherwise try to download one and fail offline with an unclear error.
+func TestMain(m *testing.M) {
+	if err := checkTerraformCLI(); err != nil {
+		fmt.Fprintf(os.Stderr, "unit tests need the Terraform CLI: %s\n", err)
+		os.Exit(1)
+	}
+
+	os.Exit(m.Run())
+}
+
+// checkTerraformCLI makes sure TF_ACC_TERRAFORM_PATH points to an existing binary, or finds terraform in PATH.
+func checkTerraformCLI() error {
+	if path := os.Getenv(terraformPathEnv); path != "" {
+		if _, err := os.Stat(path); err != nil {
+			return fmt.Errorf("%s=%s: %w", terraformPathEnv, path, err)
+		}
+		return nil
+	}
+
+	path, err := exec.LookPath("terraform")
+	if err != nil {
+		return fmt.Errorf("set %s or put terraform in PATH", terraformPathEnv)
+	}
+
+	return os.Setenv(terraformPathEnv, path)
+}
@@ -0,0 +1,246 @@
+//go:build unit
+
+package edgecenter_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/Edge-Center/terraform-provider-edgecenter/edgecenter/test/fakeapi"
+)
+
+func TestUnitProviderDefaultProjectAndRegion(t *testing.T) {
+	t.Parallel()
+	server := fakeapi.NewServer(t)
+	otherProjectID := server.AddProject("other")
+
+	config := fmt.Sprintf(`
+provider "edgecenter" {
+  permanent_api_token = "%s"
+  api_endpoint        = "%s"
+  project_name        = "%s"
+  region_name         = "%s"
+}
+
+resource "edgecenter_network" "default" {
+  name = "unit-network-default"
+  type = "vxlan"
+}
+
+resource "edgecenter_network" "override" {
+  project_id = %d
+  name       = "unit-network-override"
+  type       = "vxlan"
+}
+
+resource "edgecenter_servergroup" "default" {
+  name   = "unit-group-default"
+  policy = "affinity"
+}
+`, fakeapi.PermanentToken, server.URL, fakeapi.DefaultProjectName, fakeapi.DefaultRegionName, otherProjectID)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: unitProviderFactories(),
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testUnitCheckNoCloudObjects(server, "networks"),
+			testUnitCheckNoCloudObjects(server, "servergroups"),
+		),
+		Steps: []resource.TestStep{
+			{
+				// the follow-up plan must be empty, the defaults are stored in the computed IDs
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceExists("edgecenter_network.default"),
+					testAccCheckResourceExists("edgecenter_network.override"),
+					resource.TestCheckResourceAttr("edgecenter_network.default", "project_id", fmt.Sprint(fakeapi.DefaultProjectID)),
+					resource.TestCheckResourceAttr("edgecenter_network.default", "region_id", fmt.Sprint(fakeapi.DefaultRegionID)),
+					resource.TestCheckResourceAttr("edgecenter_servergroup.default", "project_id", fmt.Sprint(fakeapi.DefaultProjectID)),
+					testUnitCheckRequested(server, "POST", fmt.Sprintf("/cloud/v1/networks/%d/%d", fakeapi.DefaultProjectID, fakeapi.DefaultRegionID)),
+					testUnitCheckRequested(server, "POST", fmt.Sprintf("/cloud/v1/networks/%d/%d", otherProjectID, fakeapi.DefaultRegionID)),
+				),
+			},
+		},
+	})
+}
+
+func TestUnitProviderRetriesThrottledRequests(t *testing.T) {
+	t.Parallel()
+	server := fakeapi.NewServer(t)
+	server.Throttle(2)
+
+	config := server.ProviderConfig() + fmt.Sprintf(`
+resource "edgecenter_network" "unit" {
+  %s
+  name = "unit-network"
+  type = "vxlan"
+}
+`, unitCloudScope())
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: unitProviderFactories(),
+		CheckDestroy:      testUnitCheckNoCloudObjects(server, "networks"),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check:  testAccCheckResourceExists("edgecenter_network.unit"),
+			},
+		},
+	})
+}
+
+func TestUnitProviderDefaultMetadata(t *testing.T) {
+	t.Parallel()
+	server := fakeapi.NewServer(t)
+	resourceName := "edgecenter_network.unit"
+
+	template := func(owner string) string {
+		return fmt.Sprintf(`
+provider "edgecenter" {
+  permanent_api_token = "%s"
+  api_endpoint        = "%s"
+  default_metadata = {
+    owner = "%s"
+    env   = "dev"
+  }
+}
+
+resource "edgecenter_network" "unit" {
+  %s
+  name = "unit-network"
+  type = "vxlan"
+  metadata_map = {
+    env = "prod"
+    app = "web"
+  }
+}
+`, fakeapi.PermanentToken, server.URL, owner, unitCloudScope())
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: unitProviderFactories(),
+		CheckDestroy:      testUnitCheckNoCloudObjects(server, "networks"),
+		Steps: []resource.TestStep{
+			{
+				Config: template("platform"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "metadata_map.%", "2"),
+					resource.TestCheckResourceAttr(resourceName, "metadata_map.env", "prod"),
+					resource.TestCheckResourceAttr(resourceName, "metadata_all.%", "3"),
+					resource.TestCheckResourceAttr(resourceName, "metadata_all.owner", "platform"),
+					resource.TestCheckResourceAttr(resourceName, "metadata_all.env", "prod"),
+				),
+			},
+			{
+				Config: template("infra"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "metadata_map.%", "2"),
+					resource.TestCheckResourceAttr(resourceName, "metadata_all.owner", "infra"),
+				),
+			},
+		},
+	})
+}
+
+func TestUnitProviderLimitsConcurrentRequests(t *testing.T) {
+	t.Parallel()
+	server := fakeapi.NewServer(t)
+	server.SetLatency(20 * time.Millisecond)
+
+	config := fmt.Sprintf(`
+provider "edgecenter" {
+  permanent_api_token = "%s"
+  api_endpoint        = "%s"
+  max_concurrent_requests {
+    cloud = 1
+  }
+}
+
+resource "edgecenter_network" "unit" {
+  count = 4
+  %s
+  name = "unit-network-${count.index}"
+  type = "vxlan"
+}
+`, fakeapi.PermanentToken, server.URL, unitCloudScope())
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: unitProviderFactories(),
+		CheckDestroy:      testUnitCheckNoCloudObjects(server, "networks"),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: func(*terraform.State) error {
+					if n := server.MaxConcurrentRequests(); n != 1 {
+						return fmt.Errorf("expected at most 1 concurrent request, got %d", n)
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func TestUnitProviderCredentialsProfile(t *testing.T) {
+	t.Parallel()
+	server := fakeapi.NewServer(t)
+
+	credentialsFile := filepath.Join(t.TempDir(), "credentials")
+	credentials := fmt.Sprintf(`
+default:
+  permanent_api_token: wrong-token
+unit:
+  permanent_api_token: %[1]s
+  api_endpoint: %[2]s
+stale:
+  permanent_api_token: %[1]s
+  api_endpoint: %[2]s
+  edgecenter_cloud_api: %[2]s/unreachable
+`, fakeapi.PermanentToken, server.URL)
+	if err := os.WriteFile(credentialsFile, []byte(credentials), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	template := func(profile, extra string) string {
+		return fmt.Sprintf(`
+provider "edgecenter" {
+  profile          = "%s"
+  credentials_file = "%s"
+  %s
+}
+
+resource "edgecenter_network" "unit" {
+  %s
+  name = "unit-network"
+  type = "vxlan"
+}
+`, profile, credentialsFile, extra, unitCloudScope())
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: unitProviderFactories(),
+		CheckDestroy:      testUnitCheckNoCloudObjects(server, "networks"),
+		Steps: []resource.TestStep{
+			{
+				Config:      template("missing", ""),
+				ExpectError: regexp.MustCompile("profile missing not found"),
+			},
+			{
+				Config: template("unit", ""),
+				Check:  testAccCheckResourceExists("edgecenter_network.unit"),
+			},
+			{
+				// the deprecated argument set in the configuration wins over the endpoint of the profile
+				Config: template("stale", fmt.Sprintf(`edgecenter_api = "%s/cloud"`, server.URL)),
+				Check:  testAccCheckResourceExists("edgecenter_network.unit"),
+			},
+		},
+	})
+}
@@ -0,0 +1,100 @@
+//go:build unit
+
+package edgecenter_test
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/Edge-Center/terraform-provider-edgecenter/edgecenter/test/fakeapi"
+)
+
+func TestUnitBaremetalRebuild(t *testing.T) {
+	t.Parallel()
+	server := fakeapi.NewServer(t)
+	resourceName := "edgecenter_baremetal.unit"
+
+	template := func(imageID, userData string) string {
+		return server.ProviderConfig() + fmt.Sprintf(`
+resource "edgecenter_baremetal" "unit" {
+  %[1]s
+  name         = "unit-bm"
+  flavor_id    = "bm1-infrastructure-small"
+  image_id     = "%[2]s"
+  keypair_name = "unit-key"
+  user_data    = "%[3]s"
+
+  interface {
+    type = "external"
+  }
+}
+`, unitCloudScope(), imageID, userData)
+	}
+
+	var instanceID string
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: unitProviderFactories(),
+		CheckDestroy:      testUnitCheckNoCloudObjects(server, "instances"),
+		Steps: []resource.TestStep{
+			{
+				Config: template("00000000-0000-4000-8000-image0000001", "I2Nsb3VkLWNvbmZpZwo="),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceExists(resourceName),
+					resource.TestCheckResourceAttrWith(resourceName, "id", func(id string) error {
+						instanceID = id
+						return nil
+					}),
+				),
+			},
+			{
+				Config: template("00000000-0000-4000-8000-image0000002", "I2Nsb3VkLWNvbmZpZwo="),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "image_id", "00000000-0000-4000-8000-image0000002"),
+					resource.TestCheckResourceAttrWith(resourceName, "id", func(id string) error {
+						if id != instanceID {
+							return fmt.Errorf("the server was replaced: %s, expected %s", id, instanceID)
+						}
+						return nil
+					}),
+					resource.TestCheckResourceAttr(resourceName, "interface.#", "1"),
+					testUnitCheckBaremetalRebuilds(server, resourceName, 1),
+				),
+			},
+			{
+				// user_data is applied by neither an update nor a rebuild and stays pending
+				Config: template("00000000-0000-4000-8000-image0000003", "I2Nsb3VkLWNvbmZpZwpob3N0bmFtZTogdW5pdAo="),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "image_id", "00000000-0000-4000-8000-image0000003"),
+					resource.TestCheckResourceAttr(resourceName, "user_data", "I2Nsb3VkLWNvbmZpZwo="),
+					testUnitCheckBaremetalRebuilds(server, resourceName, 2),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: strings.Replace(template("00000000-0000-4000-8000-image0000003", "I2Nsb3VkLWNvbmZpZwo="),
+					`image_id     = "00000000-0000-4000-8000-image0000003"`, `apptemplate_id = "wordpress"`, 1),
+				ExpectError: regexp.MustCompile(`cannot change apptemplate_id of baremetal instance`),
+			},
+		},
+	})
+}
+
+// testUnitCheckBaremetalRebuilds verifies how many times the server was rebuilt.
+func testUnitCheckBaremetalRebuilds(server *fakeapi.Server, resourceName string, expected int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("not found: %s", resourceName)
+		}
+		path := fmt.Sprintf("/cloud/v1/bminstances/%d/%d/%s/rebuild", fakeapi.DefaultProjectID, fakeapi.DefaultRegionID, rs.Primary.ID)
+		if n := server.CountRequests("POST", path); n != expected {
+			return fmt.Errorf("rebuild requested %d times, expected %d", n, expected)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,72 @@
+//go:build unit
+
+package edgecenter_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"github.com/Edge-Center/terraform-provider-edgecenter/edgecenter"
+	"github.com/Edge-Center/terraform-provider-edgecenter/edgecenter/test/fakeapi"
+)
+
+func TestUnitDNSZoneRecord(t *testing.T) {
+	t.Parallel()
+	server := fakeapi.NewServer(t)
+	resourceName := edgecenter.DNSZoneRecordResource + ".unit"
+
+	template := func(ttl int, content string) string {
+		return server.ProviderConfig() + fmt.Sprintf(`
+resource "%s" "unit" {
+  name = "unit.test"
+}
+
+resource "%s" "unit" {
+  zone   = %s.unit.name
+  domain = "www.unit.test"
+  type   = "TXT"
+  ttl    = %d
+
+  filter {
+    type   = "geodistance"
+    limit  = 1
+    strict = true
+  }
+
+  resource_record {
+    content = "%s"
+    enabled = true
+  }
+}
+`, edgecenter.DNSZoneResource, edgecenter.DNSZoneRecordResource, edgecenter.DNSZoneResource, ttl, content)
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: unitProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: template(10, "1234"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, edgecenter.DNSZoneRecordSchemaTTL, "10"),
+					resource.TestCheckResourceAttr(resourceName, edgecenter.DNSZoneRecordSchemaResourceRecord+".0."+edgecenter.DNSZoneRecordSchemaContent, "1234"),
+				),
+			},
+			{
+				Config: template(20, "12345"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, edgecenter.DNSZoneRecordSchemaTTL, "20"),
+					resource.TestCheckResourceAttr(resourceName, edgecenter.DNSZoneRecordSchemaResourceRecord+".0."+edgecenter.DNSZoneRecordSchemaContent, "12345"),
+				),
+			},
+			{
+				PreConfig:          func() { server.DeleteDNSZone("unit.test") },
+				Config:             template(20, "12345"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
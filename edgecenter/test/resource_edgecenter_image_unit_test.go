@@ -0,0 +1,94 @@
+//go:build unit
+
+package edgecenter_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"github.com/Edge-Center/terraform-provider-edgecenter/edgecenter/test/fakeapi"
+)
+
+func TestUnitImage(t *testing.T) {
+	t.Parallel()
+	server := fakeapi.NewServer(t)
+
+	template := func(name, sshKey string) string {
+		return server.ProviderConfig() + fmt.Sprintf(`
+resource "edgecenter_volume" "unit" {
+  %[1]s
+  name = "unit-golden"
+  size = 5
+}
+
+resource "edgecenter_image" "from_volume" {
+  %[1]s
+  name      = "%[2]s"
+  volume_id = edgecenter_volume.unit.id
+  ssh_key   = "%[3]s"
+}
+
+resource "edgecenter_image" "from_url" {
+  %[1]s
+  name       = "unit-packer"
+  url        = "https://images.example.com/packer.qcow2"
+  os_distro  = "ubuntu"
+  os_version = "22.04"
+  cow_format = true
+  metadata_map = {
+    build = "42"
+  }
+}
+`, unitCloudScope(), name, sshKey)
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: unitProviderFactories(),
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testUnitCheckNoCloudObjects(server, "images"),
+			testUnitCheckNoCloudObjects(server, "volumes"),
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: template("unit-image", "allow"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceExists("edgecenter_image.from_volume"),
+					resource.TestCheckResourceAttr("edgecenter_image.from_volume", "min_disk", "5"),
+					resource.TestCheckResourceAttr("edgecenter_image.from_volume", "disk_format", "raw"),
+					resource.TestCheckResourceAttr("edgecenter_image.from_volume", "status", "active"),
+					resource.TestCheckResourceAttr("edgecenter_image.from_url", "os_distro", "ubuntu"),
+					resource.TestCheckResourceAttr("edgecenter_image.from_url", "os_version", "22.04"),
+					resource.TestCheckResourceAttr("edgecenter_image.from_url", "metadata_map.build", "42"),
+					testUnitCheckRequested(server, "POST", fmt.Sprintf("/cloud/v1/downloadimage/%d/%d", fakeapi.DefaultProjectID, fakeapi.DefaultRegionID)),
+				),
+			},
+			{
+				Config: template("unit-image-renamed", "required"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("edgecenter_image.from_volume", "name", "unit-image-renamed"),
+					resource.TestCheckResourceAttr("edgecenter_image.from_volume", "ssh_key", "required"),
+				),
+			},
+			{
+				ResourceName:            "edgecenter_image.from_url",
+				ImportState:             true,
+				ImportStateIdPrefix:     unitImportPrefix(),
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"url", "cow_format", "is_baremetal"},
+			},
+			{
+				// the imported image has no url in its state and is not replaced for the one in the configuration
+				ResourceName:        "edgecenter_image.from_url",
+				ImportState:         true,
+				ImportStateIdPrefix: unitImportPrefix(),
+				ImportStatePersist:  true,
+			},
+			{
+				Config:   template("unit-image-renamed", "required"),
+				PlanOnly: true,
+			},
+		},
+	})
+}
@@ -0,0 +1,141 @@
+//go:build unit
+
+package edgecenter_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/Edge-Center/terraform-provider-edgecenter/edgecenter/test/fakeapi"
+)
+
+func TestUnitInstanceInterface(t *testing.T) {
+	t.Parallel()
+	server := fakeapi.NewServer(t)
+	resourceName := "edgecenter_instance_interface.unit"
+
+	config := server.ProviderConfig() + fmt.Sprintf(`
+resource "edgecenter_network" "unit" {
+  %[1]s
+  name = "unit-network"
+  type = "vxlan"
+}
+
+resource "edgecenter_instance" "unit" {
+  %[1]s
+  name      = "unit-vm"
+  flavor_id = "g1-standard-1-2"
+
+  volume {
+    source     = "image"
+    image_id   = "b5b4d65d-945f-4b98-ab6f-332319c724ef"
+    size       = 5
+    boot_index = 0
+  }
+
+  interface {
+    type = "external"
+  }
+}
+
+resource "edgecenter_instance_interface" "unit" {
+  %[1]s
+  instance_id = edgecenter_instance.unit.id
+  type        = "any_subnet"
+  network_id  = edgecenter_network.unit.id
+}
+`, unitCloudScope())
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: unitProviderFactories(),
+		CheckDestroy:      testUnitCheckNoCloudObjects(server, "instances"),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceExists(resourceName),
+					resource.TestCheckResourceAttrPair(resourceName, "network_id", "edgecenter_network.unit", "id"),
+					resource.TestCheckResourceAttrSet(resourceName, "subnet_id"),
+					resource.TestCheckResourceAttrSet(resourceName, "ip_address"),
+					// the attached interface is left to the interface resource by the instance
+					resource.TestCheckResourceAttr("edgecenter_instance.unit", "interface.#", "1"),
+					resource.TestCheckResourceAttr("edgecenter_instance.unit", "interface.0.type", "external"),
+				),
+			},
+			{
+				ResourceName: resourceName,
+				ImportState:  true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					rs := s.RootModule().Resources[resourceName]
+					return unitImportPrefix() + rs.Primary.Attributes["instance_id"] + ":" + rs.Primary.ID, nil
+				},
+				ImportStateVerify: true,
+				// the type of an imported interface is derived from its network
+				ImportStateVerifyIgnore: []string{"type"},
+			},
+		},
+	})
+}
+
+func TestUnitInstanceInterfaceSecondExternal(t *testing.T) {
+	t.Parallel()
+	server := fakeapi.NewServer(t)
+	resourceName := "edgecenter_instance_interface.unit"
+
+	config := server.ProviderConfig() + fmt.Sprintf(`
+resource "edgecenter_instance" "unit" {
+  %[1]s
+  name      = "unit-vm"
+  flavor_id = "g1-standard-1-2"
+
+  volume {
+    source     = "image"
+    image_id   = "b5b4d65d-945f-4b98-ab6f-332319c724ef"
+    size       = 5
+    boot_index = 0
+  }
+
+  interface {
+    type = "external"
+  }
+}
+
+resource "edgecenter_instance_interface" "unit" {
+  %[1]s
+  instance_id = edgecenter_instance.unit.id
+  type        = "external"
+}
+`, unitCloudScope())
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: unitProviderFactories(),
+		CheckDestroy:      testUnitCheckNoCloudObjects(server, "instances"),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceExists(resourceName),
+					// both interfaces are external, the instance keeps only its own one
+					resource.TestCheckResourceAttr("edgecenter_instance.unit", "interface.#", "1"),
+					resource.TestCheckResourceAttr("edgecenter_instance.unit", "interface.0.type", "external"),
+					func(s *terraform.State) error {
+						instance := s.RootModule().Resources["edgecenter_instance.unit"].Primary.Attributes
+						attached := s.RootModule().Resources[resourceName].Primary.Attributes
+						if instance["interface.0.port_id"] == attached["port_id"] {
+							return fmt.Errorf("the instance took over the attached interface %s", attached["port_id"])
+						}
+						return nil
+					},
+				),
+			},
+			{
+				// a refresh matches the interfaces again, now by port
+				RefreshState: true,
+				Check:        resource.TestCheckResourceAttr("edgecenter_instance.unit", "interface.#", "1"),
+			},
+		},
+	})
+}
@@ -0,0 +1,348 @@
+//go:build unit
+
+package edgecenter_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/Edge-Center/terraform-provider-edgecenter/edgecenter/test/fakeapi"
+)
+
+func TestUnitInstance(t *testing.T) {
+	t.Parallel()
+	server := fakeapi.NewServer(t)
+	resourceName := "edgecenter_instance.unit"
+
+	template := func(flavorID string) string {
+		return server.ProviderConfig() + fmt.Sprintf(`
+resource "edgecenter_volume" "unit" {
+  %[1]s
+  name     = "unit-boot"
+  size     = 5
+  image_id = "00000000-0000-4000-8000-image0000000"
+}
+
+resource "edgecenter_instance" "unit" {
+  %[1]s
+  name      = "unit-vm"
+  flavor_id = "%[2]s"
+
+  volume {
+    source     = "existing-volume"
+    volume_id  = edgecenter_volume.unit.id
+    boot_index = 0
+  }
+
+  interface {
+    type = "external"
+  }
+}
+`, unitCloudScope(), flavorID)
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: unitProviderFactories(),
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testUnitCheckNoCloudObjects(server, "instances"),
+			testUnitCheckNoCloudObjects(server, "volumes"),
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: template("g1-standard-1-2"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "name", "unit-vm"),
+					resource.TestCheckResourceAttr(resourceName, "flavor_id", "g1-standard-1-2"),
+					resource.TestCheckResourceAttr(resourceName, "vm_state", "active"),
+					resource.TestCheckResourceAttr(resourceName, "volume.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "interface.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "addresses.#", "1"),
+				),
+			},
+			{
+				Config: template("g1-standard-2-4"),
+				Check:  resource.TestCheckResourceAttr(resourceName, "flavor_id", "g1-standard-2-4"),
+			},
+		},
+	})
+}
+
+func TestUnitInstanceCreatedVolumes(t *testing.T) {
+	t.Parallel()
+	server := fakeapi.NewServer(t)
+	resourceName := "edgecenter_instance.unit"
+
+	template := func(extraVolume string) string {
+		return server.ProviderConfig() + fmt.Sprintf(`
+resource "edgecenter_volume" "unit" {
+  %[1]s
+  name = "unit-data"
+  size = 1
+}
+
+resource "edgecenter_instance" "unit" {
+  %[1]s
+  name      = "unit-vm"
+  flavor_id = "g1-standard-1-2"
+
+  volume {
+    source     = "image"
+    image_id   = "b5b4d65d-945f-4b98-ab6f-332319c724ef"
+    size       = 5
+    boot_index = 0
+  }
+
+  volume {
+    source     = "new-volume"
+    name       = "unit-blank"
+    size       = 10
+    boot_index = 1
+  }
+  %[2]s
+
+  interface {
+    type = "external"
+  }
+}
+`, unitCloudScope(), extraVolume)
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: unitProviderFactories(),
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testUnitCheckNoCloudObjects(server, "instances"),
+			// the volumes created with the instance are deleted together with it
+			testUnitCheckNoCloudObjects(server, "volumes"),
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: template(""),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "volume.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "volume.*", map[string]string{
+						"source":                "image",
+						"delete_on_termination": "true",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "volume.*", map[string]string{
+						"source":                "new-volume",
+						"name":                  "unit-blank",
+						"delete_on_termination": "true",
+					}),
+				),
+			},
+			{
+				Config: template(`
+  volume {
+    source     = "existing-volume"
+    volume_id  = edgecenter_volume.unit.id
+    boot_index = 2
+  }`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "volume.#", "3"),
+					resource.TestCheckTypeSetElemAttrPair(resourceName, "volume.*.volume_id", "edgecenter_volume.unit", "id"),
+				),
+			},
+		},
+	})
+}
+
+func TestUnitInstanceAppTemplateVolume(t *testing.T) {
+	t.Parallel()
+	server := fakeapi.NewServer(t)
+	resourceName := "edgecenter_instance.unit"
+
+	template := func(appTemplateID string) string {
+		return server.ProviderConfig() + fmt.Sprintf(`
+resource "edgecenter_instance" "unit" {
+  %s
+  name      = "unit-gitlab"
+  flavor_id = "g1-standard-1-2"
+
+  volume {
+    source         = "apptemplate"
+    apptemplate_id = "%s"
+    size           = 20
+    boot_index     = 0
+  }
+
+  volume {
+    source     = "image"
+    image_id   = "b5b4d65d-945f-4b98-ab6f-332319c724ef"
+    size       = 20
+    boot_index = 1
+  }
+
+  configuration {
+    key   = "gitlab_external_url"
+    value = "https://gitlab.example.com"
+  }
+
+  interface {
+    type = "external"
+  }
+}
+`, unitCloudScope(), appTemplateID)
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: unitProviderFactories(),
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testUnitCheckNoCloudObjects(server, "instances"),
+			testUnitCheckNoCloudObjects(server, "volumes"),
+		),
+		Steps: []resource.TestStep{
+			{
+				Config:      template(""),
+				ExpectError: regexp.MustCompile(`apptemplate_id is required for a volume with source apptemplate`),
+			},
+			{
+				Config: template("gitlab"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "volume.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "volume.*", map[string]string{
+						"source":         "apptemplate",
+						"apptemplate_id": "gitlab",
+						"size":           "20",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "volume.*", map[string]string{
+						"source":   "image",
+						"image_id": "b5b4d65d-945f-4b98-ab6f-332319c724ef",
+					}),
+				),
+			},
+		},
+	})
+}
+
+func TestUnitInstancePowerActions(t *testing.T) {
+	t.Parallel()
+	server := fakeapi.NewServer(t)
+	resourceName := "edgecenter_instance.unit"
+
+	template := func(vmState, reboot, hardReboot string) string {
+		return server.ProviderConfig() + fmt.Sprintf(`
+resource "edgecenter_instance" "unit" {
+  %[1]s
+  name      = "unit-vm"
+  flavor_id = "g1-standard-1-2"
+  vm_state  = "%[2]s"
+
+  reboot_trigger = {
+    kernel = "%[3]s"
+  }
+  hard_reboot_trigger = {
+    firmware = "%[4]s"
+  }
+
+  volume {
+    source     = "image"
+    image_id   = "b5b4d65d-945f-4b98-ab6f-332319c724ef"
+    size       = 5
+    boot_index = 0
+  }
+
+  interface {
+    type = "external"
+  }
+}
+`, unitCloudScope(), vmState, reboot, hardReboot)
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: unitProviderFactories(),
+		CheckDestroy:      testUnitCheckNoCloudObjects(server, "instances"),
+		Steps: []resource.TestStep{
+			{
+				Config: template("active", "1", "1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "vm_state", "active"),
+					testUnitCheckInstanceActions(server, resourceName, "reboot", 0),
+				),
+			},
+			{
+				Config: template("active", "2", "1"),
+				Check: resource.ComposeTestCheckFunc(
+					testUnitCheckInstanceActions(server, resourceName, "reboot", 1),
+					testUnitCheckInstanceActions(server, resourceName, "powercycle", 0),
+				),
+			},
+			{
+				Config: template("suspended", "3", "2"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "vm_state", "suspended"),
+					// a suspended instance is not rebooted
+					testUnitCheckInstanceActions(server, resourceName, "reboot", 1),
+					testUnitCheckInstanceActions(server, resourceName, "powercycle", 0),
+				),
+			},
+			{
+				Config:      template("stopped", "3", "2"),
+				ExpectError: regexp.MustCompile(`cannot change vm_state of the instance from suspended to stopped`),
+			},
+			{
+				Config:      template("rescued", "3", "2"),
+				ExpectError: regexp.MustCompile(`cannot change vm_state of the instance from suspended to rescued`),
+			},
+			{
+				Config: template("active", "3", "2"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "vm_state", "active"),
+					testUnitCheckInstanceActions(server, resourceName, "resume", 1),
+				),
+			},
+			{
+				Config: template("rescued", "3", "2"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "vm_state", "rescued"),
+					testUnitCheckInstanceActions(server, resourceName, "rescue", 1),
+				),
+			},
+			{
+				Config: template("active", "3", "2"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "vm_state", "active"),
+					testUnitCheckInstanceActions(server, resourceName, "unrescue", 1),
+				),
+			},
+			{
+				Config: template("stopped", "3", "2"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "vm_state", "stopped"),
+					testUnitCheckInstanceActions(server, resourceName, "stop", 1),
+				),
+			},
+			{
+				Config: template("active", "4", "3"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "vm_state", "active"),
+					testUnitCheckInstanceActions(server, resourceName, "start", 1),
+					// the hard reboot takes precedence over the reboot
+					testUnitCheckInstanceActions(server, resourceName, "powercycle", 1),
+					testUnitCheckInstanceActions(server, resourceName, "reboot", 1),
+				),
+			},
+		},
+	})
+}
+
+// testUnitCheckInstanceActions verifies how many times the action was requested for the instance.
+func testUnitCheckInstanceActions(server *fakeapi.Server, resourceName, action string, expected int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("not found: %s", resourceName)
+		}
+		path := fmt.Sprintf("/cloud/v1/instances/%d/%d/%s/%s", fakeapi.DefaultProjectID, fakeapi.DefaultRegionID, rs.Primary.ID, action)
+		if n := server.CountRequests("POST", path); n != expected {
+			return fmt.Errorf("%s requested %d times, expected %d", action, n, expected)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,94 @@
+//go:build unit
+
+package edgecenter_test
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"github.com/Edge-Center/terraform-provider-edgecenter/edgecenter/test/fakeapi"
+)
+
+func TestUnitNetwork(t *testing.T) {
+	t.Parallel()
+	server := fakeapi.NewServer(t)
+	resourceName := "edgecenter_network.unit"
+
+	template := func(name string) string {
+		return server.ProviderConfig() + fmt.Sprintf(`
+resource "edgecenter_network" "unit" {
+  %s
+  name = "%s"
+  type = "vxlan"
+}
+`, unitCloudScope(), name)
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: unitProviderFactories(),
+		CheckDestroy:      testUnitCheckNoCloudObjects(server, "networks"),
+		Steps: []resource.TestStep{
+			{
+				Config: template("unit-network"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "name", "unit-network"),
+					resource.TestCheckResourceAttr(resourceName, "mtu", "1450"),
+				),
+			},
+			{
+				Config: template("unit-network-renamed"),
+				Check:  resource.TestCheckResourceAttr(resourceName, "name", "unit-network-renamed"),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateIdPrefix:     unitImportPrefix(),
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"last_updated", "create_router"},
+			},
+		},
+	})
+}
+
+func TestUnitNetworkImportByName(t *testing.T) {
+	t.Parallel()
+	server := fakeapi.NewServer(t)
+	resourceName := "edgecenter_network.unit"
+
+	config := server.ProviderConfig() + fmt.Sprintf(`
+resource "edgecenter_network" "unit" {
+  %s
+  name = "unit-network"
+  type = "vxlan"
+}
+`, unitCloudScope())
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: unitProviderFactories(),
+		CheckDestroy:      testUnitCheckNoCloudObjects(server, "networks"),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check:  testAccCheckResourceExists(resourceName),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateIdPrefix:     fmt.Sprintf("%s:%s:", fakeapi.DefaultProjectName, strings.ToLower(fakeapi.DefaultRegionName)),
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"last_updated", "create_router"},
+			},
+			{
+				ResourceName:        resourceName,
+				ImportState:         true,
+				ImportStateIdPrefix: fmt.Sprintf("missing:%d:", fakeapi.DefaultRegionID),
+				ExpectError:         regexp.MustCompile("wrong project segment missing"),
+			},
+		},
+	})
+}
@@ -0,0 +1,122 @@
+//go:build unit
+
+package edgecenter_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/Edge-Center/terraform-provider-edgecenter/edgecenter/test/fakeapi"
+)
+
+func TestUnitServerGroupMember(t *testing.T) {
+	t.Parallel()
+	server := fakeapi.NewServer(t)
+	resourceName := "edgecenter_servergroup_member.unit"
+
+	template := func(member string) string {
+		return server.ProviderConfig() + fmt.Sprintf(`
+resource "edgecenter_servergroup" "unit" {
+  %[1]s
+  name   = "unit-group"
+  policy = "anti-affinity"
+}
+
+resource "edgecenter_instance" "unit" {
+  %[1]s
+  name      = "unit-vm"
+  flavor_id = "g1-standard-1-2"
+
+  volume {
+    source     = "image"
+    image_id   = "b5b4d65d-945f-4b98-ab6f-332319c724ef"
+    size       = 5
+    boot_index = 0
+  }
+
+  interface {
+    type = "external"
+  }
+}
+%[2]s
+`, unitCloudScope(), member)
+	}
+	member := fmt.Sprintf(`
+resource "edgecenter_servergroup_member" "unit" {
+  %s
+  servergroup_id = edgecenter_servergroup.unit.id
+  instance_id    = edgecenter_instance.unit.id
+}
+`, unitCloudScope())
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: unitProviderFactories(),
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testUnitCheckNoCloudObjects(server, "instances"),
+			testUnitCheckNoCloudObjects(server, "servergroups"),
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: template(member),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceExists(resourceName),
+					resource.TestCheckResourceAttrPair(resourceName, "id", "edgecenter_instance.unit", "id"),
+					resource.TestCheckResourceAttr(resourceName, "instance_name", "unit-vm"),
+					resource.TestCheckResourceAttr(resourceName, "policy", "anti-affinity"),
+					testUnitCheckInstanceActions(server, "edgecenter_instance.unit", "put_into_servergroup", 1),
+				),
+			},
+			{
+				ResourceName: resourceName,
+				ImportState:  true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					rs := s.RootModule().Resources[resourceName]
+					return unitImportPrefix() + rs.Primary.Attributes["servergroup_id"] + ":" + rs.Primary.ID, nil
+				},
+				ImportStateVerify: true,
+			},
+			{
+				// the instance leaves the group in place
+				Config: template(""),
+				Check: resource.ComposeTestCheckFunc(
+					testUnitCheckInstanceActions(server, "edgecenter_instance.unit", "remove_from_servergroup", 1),
+					testUnitCheckInstanceActions(server, "edgecenter_instance.unit", "put_into_servergroup", 1),
+				),
+			},
+			{
+				PreConfig:   func() { server.SetTaskState("ERROR") },
+				Config:      template(member),
+				ExpectError: regexp.MustCompile(`(?s)anti-affinity server group unit-group.*separate host`),
+			},
+			{
+				PreConfig: func() { server.SetTaskState("FINISHED") },
+				Config:    template(""),
+			},
+		},
+	})
+}
+
+func TestUnitServerGroupPolicyValidation(t *testing.T) {
+	t.Parallel()
+	server := fakeapi.NewServer(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: unitProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: server.ProviderConfig() + fmt.Sprintf(`
+resource "edgecenter_servergroup" "unit" {
+  %s
+  name   = "unit-group"
+  policy = "soft-anti-affinity"
+}
+`, unitCloudScope()),
+				ExpectError: regexp.MustCompile(`invalid server group policy "soft-anti-affinity"`),
+			},
+		},
+	})
+}
@@ -0,0 +1,46 @@
+//go:build unit
+
+package edgecenter_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"github.com/Edge-Center/terraform-provider-edgecenter/edgecenter"
+	"github.com/Edge-Center/terraform-provider-edgecenter/edgecenter/test/fakeapi"
+)
+
+func TestUnitStorageS3(t *testing.T) {
+	t.Parallel()
+	server := fakeapi.NewServer(t)
+	resourceName := "edgecenter_storage_s3.unit"
+
+	config := server.ProviderConfig() + `
+resource "edgecenter_storage_s3" "unit" {
+  name     = "unit"
+  location = "s-ed1"
+}
+
+resource "edgecenter_storage_s3_bucket" "unit" {
+  storage_id = edgecenter_storage_s3.unit.storage_id
+  name       = "unit-bucket"
+}
+`
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: unitProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceExists(resourceName),
+					testAccCheckResourceExists("edgecenter_storage_s3_bucket.unit"),
+					resource.TestCheckResourceAttr(resourceName, edgecenter.StorageSchemaName, "unit"),
+					resource.TestCheckResourceAttrSet(resourceName, edgecenter.StorageS3SchemaGenerateAccessKey),
+					resource.TestCheckResourceAttrSet(resourceName, edgecenter.StorageS3SchemaGenerateSecretKey),
+				),
+			},
+		},
+	})
+}
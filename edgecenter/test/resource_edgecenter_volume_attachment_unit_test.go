@@ -0,0 +1,87 @@
+//go:build unit
+
+package edgecenter_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/Edge-Center/terraform-provider-edgecenter/edgecenter/test/fakeapi"
+)
+
+func TestUnitVolumeAttachment(t *testing.T) {
+	t.Parallel()
+	server := fakeapi.NewServer(t)
+	resourceName := "edgecenter_volume_attachment.unit"
+
+	config := server.ProviderConfig() + fmt.Sprintf(`
+resource "edgecenter_volume" "boot" {
+  %[1]s
+  name     = "unit-boot"
+  size     = 5
+  image_id = "b5b4d65d-945f-4b98-ab6f-332319c724ef"
+}
+
+resource "edgecenter_volume" "data" {
+  %[1]s
+  name = "unit-data"
+  size = 1
+}
+
+resource "edgecenter_instance" "unit" {
+  %[1]s
+  name      = "unit-vm"
+  flavor_id = "g1-standard-1-2"
+
+  volume {
+    source     = "existing-volume"
+    volume_id  = edgecenter_volume.boot.id
+    boot_index = 0
+  }
+
+  interface {
+    type = "external"
+  }
+}
+
+resource "edgecenter_volume_attachment" "unit" {
+  %[1]s
+  instance_id    = edgecenter_instance.unit.id
+  volume_id      = edgecenter_volume.data.id
+  attachment_tag = "data"
+}
+`, unitCloudScope())
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: unitProviderFactories(),
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testUnitCheckNoCloudObjects(server, "instances"),
+			testUnitCheckNoCloudObjects(server, "volumes"),
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceExists(resourceName),
+					resource.TestCheckResourceAttrPair(resourceName, "volume_id", "edgecenter_volume.data", "id"),
+					resource.TestCheckResourceAttr(resourceName, "device", "/dev/vdb"),
+					// the attached volume is left to the attachment by the instance
+					resource.TestCheckResourceAttr("edgecenter_instance.unit", "volume.#", "1"),
+				),
+			},
+			{
+				ResourceName: resourceName,
+				ImportState:  true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					rs := s.RootModule().Resources[resourceName]
+					return unitImportPrefix() + rs.Primary.Attributes["instance_id"] + ":" + rs.Primary.ID, nil
+				},
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"attachment_tag"},
+			},
+		},
+	})
+}
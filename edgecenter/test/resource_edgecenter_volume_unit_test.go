@@ -0,0 +1,99 @@
+//go:build unit
+
+package edgecenter_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"github.com/Edge-Center/terraform-provider-edgecenter/edgecenter/test/fakeapi"
+)
+
+func TestUnitVolume(t *testing.T) {
+	t.Parallel()
+	server := fakeapi.NewServer(t)
+	resourceName := "edgecenter_volume.unit"
+
+	template := func(name string, size int, typeName string) string {
+		return server.ProviderConfig() + fmt.Sprintf(`
+resource "edgecenter_volume" "unit" {
+  %s
+  name      = "%s"
+  size      = %d
+  type_name = "%s"
+}
+`, unitCloudScope(), name, size, typeName)
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: unitProviderFactories(),
+		CheckDestroy:      testUnitCheckNoCloudObjects(server, "volumes"),
+		Steps: []resource.TestStep{
+			{
+				Config: template("unit-volume", 1, "standard"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "name", "unit-volume"),
+					resource.TestCheckResourceAttr(resourceName, "size", "1"),
+					resource.TestCheckResourceAttr(resourceName, "type_name", "standard"),
+				),
+			},
+			{
+				Config: template("unit-volume-renamed", 2, "ssd_hiiops"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", "unit-volume-renamed"),
+					resource.TestCheckResourceAttr(resourceName, "size", "2"),
+					resource.TestCheckResourceAttr(resourceName, "type_name", "ssd_hiiops"),
+				),
+			},
+			{
+				PreConfig:          func() { server.DeleteCloudObjects("volumes") },
+				Config:             template("unit-volume-renamed", 2, "ssd_hiiops"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: template("unit-volume-renamed", 2, "ssd_hiiops"),
+				Check:  testAccCheckResourceExists(resourceName),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateIdPrefix:     unitImportPrefix(),
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"last_updated"},
+			},
+		},
+	})
+}
+
+func TestUnitVolumeTaskTimeout(t *testing.T) {
+	t.Parallel()
+	server := fakeapi.NewServer(t)
+	server.SetTaskState("RUNNING")
+
+	config := server.ProviderConfig() + fmt.Sprintf(`
+resource "edgecenter_volume" "unit" {
+  %s
+  name = "unit-volume"
+  size = 1
+
+  timeouts {
+    create = "3s"
+  }
+}
+`, unitCloudScope())
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: unitProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config:      config,
+				ExpectError: regexp.MustCompile(`timeout while waiting for task`),
+			},
+		},
+	})
+}
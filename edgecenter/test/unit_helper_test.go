@@ -0,0 +1,54 @@
+//go:build unit
+
+package edgecenter_test
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/Edge-Center/terraform-provider-edgecenter/edgecenter"
+	"github.com/Edge-Center/terraform-provider-edgecenter/edgecenter/test/fakeapi"
+)
+
+// unitProviderFactories returns a fresh provider per test, so parallel unit tests
+// never share a provider configured against another fake server.
+func unitProviderFactories() map[string]func() (*schema.Provider, error) {
+	return map[string]func() (*schema.Provider, error){
+		"edgecenter": func() (*schema.Provider, error) {
+			return edgecenter.Provider(), nil
+		},
+	}
+}
+
+// unitCloudScope is the project/region pair every fake cloud resource is created in.
+func unitCloudScope() string {
+	return fmt.Sprintf("project_id = %d\n  region_id = %d", fakeapi.DefaultProjectID, fakeapi.DefaultRegionID)
+}
+
+// unitImportPrefix returns the "projectID:regionID:" prefix used by cloud importers.
+func unitImportPrefix() string {
+	return fmt.Sprintf("%d:%d:", fakeapi.DefaultProjectID, fakeapi.DefaultRegionID)
+}
+
+// testUnitCheckNoCloudObjects verifies that the fake server holds no objects of the given kind.
+func testUnitCheckNoCloudObjects(server *fakeapi.Server, kind string) resource.TestCheckFunc {
+	return func(_ *terraform.State) error {
+		if n := server.CloudObjects(kind); n != 0 {
+			return fmt.Errorf("%d %s still exist", n, kind)
+		}
+		return nil
+	}
+}
+
+// testUnitCheckRequested verifies that the fake server received a request with the given method and path prefix.
+func testUnitCheckRequested(server *fakeapi.Server, method, pathPrefix string) resource.TestCheckFunc {
+	return func(_ *terraform.State) error {
+		if server.CountRequests(method, pathPrefix) == 0 {
+			return fmt.Errorf("no %s %s request was made", method, pathPrefix)
+		}
+		return nil
+	}
+}
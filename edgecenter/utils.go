@@ -3,6 +3,7 @@ package edgecenter
 import (
 	"fmt"
 	"log"
+	"net/http"
 	"net/url"
 	"strconv"
 	"strings"
@@ -30,6 +31,22 @@ type Config struct {
 	CDNClient     cdn.ClientService
 	StorageClient *storageSDK.SDK
 	DNSClient     *dnsSDK.Client
+
+	// StorageHTTPClient is passed to every storage request, as the storage SDK has no option to set its transport.
+	StorageHTTPClient *http.Client
+
+	// Project and region used by cloud resources and data sources which don't define their own.
+	ProjectID   int
+	ProjectName string
+	RegionID    int
+	RegionName  string
+
+	// DefaultMetadata is merged into the metadata of every cloud resource with a metadata_map.
+	DefaultMetadata map[string]string
+
+	// projects and regions cache the name lookups for the lifetime of the configured provider.
+	projects nameResolver
+	regions  nameResolver
 }
 
 // MapStructureDecoder decodes the given map into the provided structure using the specified decoder configuration.
@@ -43,33 +60,64 @@ func MapStructureDecoder(strct interface{}, v *map[string]interface{}, config *m
 	return decoder.Decode(*v)
 }
 
-// ImportStringParser parses a string containing project ID, region ID, and another field,
-// and returns them as separate values along with any error encountered.
-func ImportStringParser(infoStr string) (projectID int, regionID int, id3 string, err error) { //nolint: nonamedreturns
+// ImportStringParser parses an import ID of the form "project:region:id", where project and region
+// are given either by ID or by name, and returns the project ID, the region ID and the ID of the resource.
+func ImportStringParser(config *Config, infoStr string) (projectID int, regionID int, id3 string, err error) { //nolint: nonamedreturns
 	log.Printf("[DEBUG] Input id string: %s", infoStr)
 	infoStrings := strings.Split(infoStr, ":")
 	if len(infoStrings) != 3 {
-		err = fmt.Errorf("failed import: wrong input id: %s", infoStr)
+		err = fmt.Errorf("failed import: wrong input id: %s, expected <project>:<region>:<id>", infoStr)
 		return
 	}
 
-	id1, id2, id3 := infoStrings[0], infoStrings[1], infoStrings[2]
+	id3 = infoStrings[2]
+	projectID, regionID, err = parseImportScope(config, infoStrings[0], infoStrings[1])
 
-	projectID, err = strconv.Atoi(id1)
+	return
+}
+
+// parseImportScope resolves the project and region segments of an import ID, each given either by ID or by name.
+func parseImportScope(config *Config, project, region string) (int, int, error) {
+	projectID, err := parseImportSegment("project", project, config.resolveProject)
 	if err != nil {
-		return
+		return 0, 0, err
 	}
-	regionID, err = strconv.Atoi(id2)
+	regionID, err := parseImportSegment("region", region, config.resolveRegion)
 	if err != nil {
-		return
+		return 0, 0, err
 	}
 
-	return
+	return projectID, regionID, nil
+}
+
+// parseImportSegment returns the ID given in the segment, or resolves the segment as a name of the given kind.
+func parseImportSegment(kind, segment string, resolve func(id int, name string) (int, error)) (int, error) {
+	if segment == "" {
+		return 0, fmt.Errorf("failed import: %s segment is empty", kind)
+	}
+	if id, err := strconv.Atoi(segment); err == nil {
+		return id, nil
+	}
+
+	id, err := resolve(0, segment)
+	if err != nil {
+		return 0, fmt.Errorf("failed import: wrong %s segment %s: %w", kind, segment, err)
+	}
+
+	return id, nil
 }
 
 // CreateClient creates a new edgecloud.ServiceClient.
-func CreateClient(provider *edgecloud.ProviderClient, d *schema.ResourceData, endpoint string, version string) (*edgecloud.ServiceClient, error) {
-	projectID, err := GetProject(provider, d.Get("project_id").(int), d.Get("project_name").(string))
+// The project and region of the resource take precedence over the provider defaults.
+func CreateClient(config *Config, d *schema.ResourceData, endpoint string, version string) (*edgecloud.ServiceClient, error) {
+	projectID, projectName := d.Get("project_id").(int), d.Get("project_name").(string)
+	if projectID == 0 && projectName == "" {
+		projectID, projectName = config.ProjectID, config.ProjectName
+	}
+	if projectID == 0 && projectName == "" {
+		return nil, fmt.Errorf("either 'project_id' or 'project_name' must be specified in the resource or in the provider")
+	}
+	projectID, err := config.resolveProject(projectID, projectName)
 	if err != nil {
 		return nil, err
 	}
@@ -79,13 +127,21 @@ func CreateClient(provider *edgecloud.ProviderClient, d *schema.ResourceData, en
 	rawRegionID := d.Get("region_id")
 	rawRegionName := d.Get("region_name")
 	if rawRegionID != nil && rawRegionName != nil {
-		regionID, err = GetRegion(provider, rawRegionID.(int), rawRegionName.(string))
+		regionID = rawRegionID.(int)
+		regionName := rawRegionName.(string)
+		if regionID == 0 && regionName == "" {
+			regionID, regionName = config.RegionID, config.RegionName
+		}
+		if regionID == 0 && regionName == "" {
+			return nil, fmt.Errorf("either 'region_id' or 'region_name' must be specified in the resource or in the provider")
+		}
+		regionID, err = config.resolveRegion(regionID, regionName)
 		if err != nil {
 			return nil, fmt.Errorf("failed to get region: %w", err)
 		}
 	}
 
-	client, err := edgecenter.ClientServiceFromProvider(provider, edgecloud.EndpointOpts{
+	client, err := edgecenter.ClientServiceFromProvider(config.Provider, edgecloud.EndpointOpts{
 		Name:    endpoint,
 		Region:  regionID,
 		Project: projectID,
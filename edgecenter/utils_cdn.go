@@ -0,0 +1,113 @@
+package edgecenter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	eccdn "github.com/Edge-Center/edgecentercdn-go/edgecenter"
+)
+
+const (
+	cdnClientTimeout = time.Minute
+	// cdnErrorBodyLimit caps how much of a failed response ends up in the error message.
+	cdnErrorBodyLimit = 4096
+)
+
+// cdnAPIError is an error response of the CDN API together with its HTTP status code.
+type cdnAPIError struct {
+	StatusCode int
+	eccdn.ErrorResponse
+}
+
+func (e *cdnAPIError) Error() string {
+	return fmt.Sprintf("%d: %s", e.StatusCode, e.ErrorResponse.Error())
+}
+
+// newCDNAPIError builds the error of a failed response. A body which isn't a JSON error response,
+// like an HTML page of a proxy, becomes the message, so the status code is kept in any case.
+func newCDNAPIError(resp *http.Response) *cdnAPIError {
+	errResp := &cdnAPIError{StatusCode: resp.StatusCode}
+
+	raw, err := io.ReadAll(io.LimitReader(resp.Body, cdnErrorBodyLimit))
+	if err != nil {
+		errResp.Message = fmt.Sprintf("read err resp: %s", err)
+		return errResp
+	}
+	if err := json.Unmarshal(raw, &errResp.ErrorResponse); err != nil || (errResp.Message == "" && errResp.Errors == nil) {
+		errResp.ErrorResponse = eccdn.ErrorResponse{Message: strings.TrimSpace(string(raw))}
+	}
+	if errResp.Message == "" && errResp.Errors == nil {
+		errResp.Message = http.StatusText(resp.StatusCode)
+	}
+
+	return errResp
+}
+
+// cdnClient implements eccdn.Requester like the CDN SDK client does,
+// but keeps the status code of failed responses so they can be classified.
+type cdnClient struct {
+	httpc   *http.Client
+	baseURL string
+	ua      string
+	signer  eccdn.RequestSignerFunc
+}
+
+var _ eccdn.Requester = (*cdnClient)(nil)
+
+func newCDNClient(baseURL, ua string, transport http.RoundTripper, signer eccdn.RequestSignerFunc) *cdnClient {
+	return &cdnClient{
+		httpc:   &http.Client{Timeout: cdnClientTimeout, Transport: transport},
+		baseURL: baseURL,
+		ua:      ua,
+		signer:  signer,
+	}
+}
+
+func (c *cdnClient) Request(ctx context.Context, method, path string, payload interface{}, result interface{}) error {
+	var body io.Reader
+	if payload != nil {
+		payloadBuf := new(bytes.Buffer)
+		if err := json.NewEncoder(payloadBuf).Encode(payload); err != nil {
+			return fmt.Errorf("encode req payload: %w", err)
+		}
+		body = payloadBuf
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, body)
+	if err != nil {
+		return fmt.Errorf("new request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.ua != "" {
+		req.Header.Set("User-Agent", c.ua)
+	}
+	if c.signer != nil {
+		if err := c.signer.Sign(req); err != nil {
+			return fmt.Errorf("sign request: %w", err)
+		}
+	}
+
+	resp, err := c.httpc.Do(req)
+	if err != nil {
+		return fmt.Errorf("do request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusBadRequest {
+		return newCDNAPIError(resp)
+	}
+
+	if result != nil && resp.StatusCode != http.StatusNoContent {
+		if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+			return fmt.Errorf("decode successful resp %d: %w", resp.StatusCode, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,63 @@
+package edgecenter
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCDNClientErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      int
+		body        string
+		wantMessage string
+		notFound    bool
+	}{
+		{
+			name:        "json error response",
+			status:      http.StatusBadRequest,
+			body:        `{"message": "invalid origin group"}`,
+			wantMessage: "invalid origin group",
+		},
+		{
+			name:        "html page of a proxy",
+			status:      http.StatusNotFound,
+			body:        "<html><body>404 Not Found</body></html>\n",
+			wantMessage: "<html><body>404 Not Found</body></html>",
+			notFound:    true,
+		},
+		{
+			name:        "empty body",
+			status:      http.StatusNotFound,
+			wantMessage: "Not Found",
+			notFound:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			err := newCDNClient(server.URL, "", nil, nil).Request(context.Background(), http.MethodGet, "/cdn/resources/1", nil, nil)
+			apiErr, ok := err.(*cdnAPIError)
+			if !ok {
+				t.Fatalf("expected a *cdnAPIError, got %T: %v", err, err)
+			}
+			if apiErr.StatusCode != tt.status {
+				t.Errorf("status code = %d, want %d", apiErr.StatusCode, tt.status)
+			}
+			if apiErr.Message != tt.wantMessage {
+				t.Errorf("message = %q, want %q", apiErr.Message, tt.wantMessage)
+			}
+			if isNotFound(err) != tt.notFound {
+				t.Errorf("isNotFound() = %t, want %t", !tt.notFound, tt.notFound)
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package edgecenter
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	dnssdk "github.com/Edge-Center/edgecenter-dns-sdk-go"
+	edgecloud "github.com/Edge-Center/edgecentercloud-go"
+)
+
+// statusCoder is implemented by the go-openapi errors returned by the storage client.
+type statusCoder interface {
+	IsCode(code int) bool
+}
+
+// isNotFound reports whether err returned by any of the cloud, CDN, DNS or storage clients
+// means that the requested object does not exist.
+func isNotFound(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var errDefault404 edgecloud.Default404Error
+	if errors.As(err, &errDefault404) {
+		return true
+	}
+	var errUnexpectedCode edgecloud.UnexpectedResponseCodeError
+	if errors.As(err, &errUnexpectedCode) && errUnexpectedCode.Actual == http.StatusNotFound {
+		return true
+	}
+
+	var errCDN *cdnAPIError
+	if errors.As(err, &errCDN) && errCDN.StatusCode == http.StatusNotFound {
+		return true
+	}
+
+	var errDNS dnssdk.APIError
+	if errors.As(err, &errDNS) && errDNS.StatusCode == http.StatusNotFound {
+		return true
+	}
+
+	var errStorage statusCoder
+	if errors.As(err, &errStorage) && errStorage.IsCode(http.StatusNotFound) {
+		return true
+	}
+
+	return false
+}
+
+// removeIfNotFound drops the resource from the state when err means that it was deleted outside of Terraform,
+// so the next plan recreates it. It returns true when the resource was removed.
+// Data sources have no ID yet while reading, so for them a not-found error is always reported.
+func removeIfNotFound(d *schema.ResourceData, kind string, err error) bool {
+	if d.Id() == "" || !isNotFound(err) {
+		return false
+	}
+
+	log.Printf("[WARN] Removing %s %s because resource doesn't exist anymore", kind, d.Id())
+	d.SetId("")
+
+	return true
+}
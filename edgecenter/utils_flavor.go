@@ -0,0 +1,298 @@
+package edgecenter
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/Edge-Center/edgecentercloud-go/edgecenter/flavor/v1/flavors"
+)
+
+const (
+	FlavorsPoint   = "flavors"
+	bmFlavorsPoint = "bmflavors"
+)
+
+// flavorInfo is a flavor with the fields of the API response the SDK doesn't decode.
+type flavorInfo struct {
+	flavors.Flavor
+	Disabled            bool              `json:"disabled"`
+	HardwareDescription map[string]string `json:"hardware_description"`
+}
+
+func (f flavorInfo) hasGPU() bool {
+	return f.HardwareDescription["gpu"] != ""
+}
+
+// flavorFilterSchema returns the filters shared by the flavor data sources.
+func flavorFilterSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"project_id": {
+			Type:          schema.TypeInt,
+			Optional:      true,
+			Description:   "The ID of the project. Either 'project_id' or 'project_name' must be specified, unless the provider sets a default project.",
+			ConflictsWith: []string{"project_name"},
+		},
+		"project_name": {
+			Type:          schema.TypeString,
+			Optional:      true,
+			Description:   "The name of the project. Either 'project_id' or 'project_name' must be specified, unless the provider sets a default project.",
+			ConflictsWith: []string{"project_id"},
+		},
+		"region_id": {
+			Type:          schema.TypeInt,
+			Optional:      true,
+			Description:   "The ID of the region. Either 'region_id' or 'region_name' must be specified, unless the provider sets a default region.",
+			ConflictsWith: []string{"region_name"},
+		},
+		"region_name": {
+			Type:          schema.TypeString,
+			Optional:      true,
+			Description:   "The name of the region. Either 'region_id' or 'region_name' must be specified, unless the provider sets a default region.",
+			ConflictsWith: []string{"region_id"},
+		},
+		"is_baremetal": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Description: "Set to true to get baremetal flavors instead of virtual machine ones.",
+		},
+		"min_vcpus": {
+			Type:        schema.TypeInt,
+			Optional:    true,
+			Description: "The minimum number of vCPUs.",
+		},
+		"max_vcpus": {
+			Type:        schema.TypeInt,
+			Optional:    true,
+			Description: "The maximum number of vCPUs.",
+		},
+		"min_ram": {
+			Type:        schema.TypeInt,
+			Optional:    true,
+			Description: "The minimum RAM in MB.",
+		},
+		"max_ram": {
+			Type:        schema.TypeInt,
+			Optional:    true,
+			Description: "The maximum RAM in MB.",
+		},
+		"has_gpu": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Description: "Set to true to get only flavors with a GPU, or to false to get only flavors without one.",
+		},
+		"include_disabled": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Description: "Set to true to also get disabled flavors.",
+		},
+		"hardware_description": {
+			Type:        schema.TypeMap,
+			Optional:    true,
+			Description: `The hardware description the flavors must contain, case-insensitive, for example {cpu = "Xeon", gpu = "A100"}.`,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+		},
+		"include_prices": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Description: "Set to true to get the prices of the flavors, where the API exposes them.",
+		},
+	}
+}
+
+// flavorSchema returns the attributes of a found flavor.
+func flavorSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"flavor_id": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The ID of the flavor, for example 'g1-standard-2-4'.",
+		},
+		"flavor_name": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The name of the flavor.",
+		},
+		"vcpus": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "The number of vCPUs.",
+		},
+		"ram": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "The RAM in MB.",
+		},
+		"disabled": {
+			Type:        schema.TypeBool,
+			Computed:    true,
+			Description: "Whether the flavor is disabled for new instances.",
+		},
+		"gpu": {
+			Type:        schema.TypeBool,
+			Computed:    true,
+			Description: "Whether the flavor has a GPU.",
+		},
+		"hardware": {
+			Type:        schema.TypeMap,
+			Computed:    true,
+			Description: "The hardware description of the flavor, for example {cpu = \"...\", ram = \"...\"}.",
+			Elem:        &schema.Schema{Type: schema.TypeString},
+		},
+		"price_per_hour": {
+			Type:        schema.TypeFloat,
+			Computed:    true,
+			Description: "The price per hour, if prices are included and exposed.",
+		},
+		"price_per_month": {
+			Type:        schema.TypeFloat,
+			Computed:    true,
+			Description: "The price per month, if prices are included and exposed.",
+		},
+		"currency_code": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The currency of the prices.",
+		},
+		"price_status": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Whether the price of the flavor is shown, hidden or unknown.",
+		},
+	}
+}
+
+// listFilteredFlavors lists the flavors of the project and region matching the filters of d,
+// ordered from the cheapest and, among equally priced ones, from the smallest. Flavors without a price come last.
+func listFilteredFlavors(config *Config, d *schema.ResourceData) ([]flavorInfo, error) {
+	point := FlavorsPoint
+	if d.Get("is_baremetal").(bool) {
+		point = bmFlavorsPoint
+	}
+	client, err := CreateClient(config, d, point, VersionPointV1)
+	if err != nil {
+		return nil, err
+	}
+
+	includePrices := d.Get("include_prices").(bool)
+	pages, err := flavors.List(client, flavors.ListOpts{IncludePrices: &includePrices}).AllPages()
+	if err != nil {
+		return nil, fmt.Errorf("cannot list flavors: %w", err)
+	}
+	var all []flavorInfo
+	if err := flavors.ExtractFlavorsInto(pages, &all); err != nil {
+		return nil, fmt.Errorf("cannot list flavors: %w", err)
+	}
+
+	var hasGPU *bool
+	if rawConfig := d.GetRawConfig(); !rawConfig.IsNull() && !rawConfig.GetAttr("has_gpu").IsNull() {
+		v := d.Get("has_gpu").(bool)
+		hasGPU = &v
+	}
+	hardware := d.Get("hardware_description").(map[string]interface{})
+
+	result := make([]flavorInfo, 0, len(all))
+	for _, f := range all {
+		switch {
+		case f.Disabled && !d.Get("include_disabled").(bool),
+			!intInRange(f.VCPUS, d.Get("min_vcpus").(int), d.Get("max_vcpus").(int)),
+			!intInRange(f.RAM, d.Get("min_ram").(int), d.Get("max_ram").(int)),
+			hasGPU != nil && f.hasGPU() != *hasGPU,
+			!hardwareContains(f.HardwareDescription, hardware):
+			continue
+		}
+		result = append(result, f)
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		pi, iPriced := flavorPrice(result[i])
+		pj, jPriced := flavorPrice(result[j])
+		if iPriced != jPriced {
+			return iPriced
+		}
+		if pi != pj {
+			return pi < pj
+		}
+		if result[i].VCPUS != result[j].VCPUS {
+			return result[i].VCPUS < result[j].VCPUS
+		}
+		if result[i].RAM != result[j].RAM {
+			return result[i].RAM < result[j].RAM
+		}
+		return result[i].FlavorID < result[j].FlavorID
+	})
+
+	return result, nil
+}
+
+// flavorToMap converts a flavor into the attributes of flavorSchema.
+func flavorToMap(f flavorInfo) map[string]interface{} {
+	hardware := make(map[string]interface{}, len(f.HardwareDescription))
+	for k, v := range f.HardwareDescription {
+		hardware[k] = v
+	}
+
+	result := map[string]interface{}{
+		"flavor_id":       f.FlavorID,
+		"flavor_name":     f.FlavorName,
+		"vcpus":           f.VCPUS,
+		"ram":             f.RAM,
+		"disabled":        f.Disabled,
+		"gpu":             f.hasGPU(),
+		"hardware":        hardware,
+		"price_per_hour":  0.0,
+		"price_per_month": 0.0,
+		"currency_code":   "",
+		"price_status":    "",
+	}
+	if f.PricePerHour != nil {
+		result["price_per_hour"] = f.PricePerHour.InexactFloat64()
+	}
+	if f.PricePerMonth != nil {
+		result["price_per_month"] = f.PricePerMonth.InexactFloat64()
+	}
+	if f.CurrencyCode != nil && f.CurrencyCode.Currency != nil {
+		result["currency_code"] = f.CurrencyCode.String()
+	}
+	if f.PriceStatus != nil {
+		result["price_status"] = *f.PriceStatus
+	}
+
+	return result
+}
+
+// flavorPrice returns the hourly price of a flavor and whether the flavor has one.
+func flavorPrice(f flavorInfo) (float64, bool) {
+	if f.PricePerHour == nil {
+		return 0, false
+	}
+	return f.PricePerHour.InexactFloat64(), true
+}
+
+// intInRange checks v against the bounds, where a zero bound is not set.
+func intInRange(v, lower, upper int) bool {
+	return (lower == 0 || v >= lower) && (upper == 0 || v <= upper)
+}
+
+// hardwareContains checks that every filter value is contained in the hardware description under its key.
+func hardwareContains(description map[string]string, filter map[string]interface{}) bool {
+	for k, v := range filter {
+		if !strings.Contains(strings.ToLower(description[k]), strings.ToLower(v.(string))) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// flavorIDsHash identifies a list of flavors.
+func flavorIDsHash(list []flavorInfo) string {
+	ids := make([]string, len(list))
+	for i, f := range list {
+		ids[i] = f.FlavorID
+	}
+
+	return fmt.Sprint(schema.HashString(strings.Join(ids, ",")))
+}
@@ -1,15 +1,23 @@
 package edgecenter
 
 import (
+	"bytes"
+	"context"
 	"crypto/md5"
+	"encoding/base64"
 	"encoding/binary"
-	"errors"
 	"fmt"
 	"io"
 	"log"
 	"reflect"
+	"sort"
+	"strings"
+	"time"
 
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/mitchellh/mapstructure"
 
 	edgecloud "github.com/Edge-Center/edgecentercloud-go"
@@ -18,6 +26,7 @@ import (
 	"github.com/Edge-Center/edgecentercloud-go/edgecenter/securitygroup/v1/securitygroups"
 	"github.com/Edge-Center/edgecentercloud-go/edgecenter/servergroup/v1/servergroups"
 	"github.com/Edge-Center/edgecentercloud-go/edgecenter/task/v1/tasks"
+	"github.com/Edge-Center/edgecentercloud-go/edgecenter/volume/v1/volumes"
 )
 
 var instanceDecoderConfig = &mapstructure.DecoderConfig{
@@ -141,25 +150,151 @@ func extractMetadataMap(metadata map[string]interface{}) instances.MetadataSetOp
 }
 
 // extractInstanceVolumesMap converts a slice of instance volumes into a map of volume IDs to boolean values.
+// Volumes created together with the instance are skipped, as they can't be attached or detached.
 func extractInstanceVolumesMap(volumes []interface{}) map[string]bool {
 	result := make(map[string]bool)
 	for _, volume := range volumes {
 		v := volume.(map[string]interface{})
+		if !isExistingVolume(v) {
+			continue
+		}
 		result[v["volume_id"].(string)] = true
 	}
 	return result
 }
 
-// extractVolumesIntoMap converts a slice of volumes into a map with volume_id as the key.
+// extractVolumesIntoMap converts a slice of volumes into a map with the volume ID as the key.
+// Volumes created together with the instance are keyed by their id, existing volumes by volume_id.
 func extractVolumesIntoMap(volumes []interface{}) map[string]map[string]interface{} {
 	result := make(map[string]map[string]interface{}, len(volumes))
 	for _, volume := range volumes {
 		vol := volume.(map[string]interface{})
+		if id, _ := vol["id"].(string); id != "" {
+			result[id] = vol
+			continue
+		}
 		result[vol["volume_id"].(string)] = vol
 	}
 	return result
 }
 
+// isExistingVolume checks if a volume of the instance is an existing volume rather than one created with the instance.
+func isExistingVolume(volume map[string]interface{}) bool {
+	source, _ := volume["source"].(string)
+	return source == "" || types.VolumeSource(source) == types.ExistingVolume
+}
+
+// setCreatedVolumeIDs sets the id of the volumes created together with the instance.
+// The task reports only the IDs of the created volumes, so each one is matched to the block it was created from
+// by its source, size, type and name. Blocks naming their image or snapshot are matched first, then blocks that set
+// more of the others, so a less specific block, like one of an application template, doesn't take the volume
+// of a more specific one. Blocks alike in all of them are interchangeable.
+func setCreatedVolumeIDs(d *schema.ResourceData, requested []interface{}, created []volumes.Volume) error {
+	blocks := make([]map[string]interface{}, 0, len(requested))
+	for _, volume := range requested {
+		vol := volume.(map[string]interface{})
+		if !isExistingVolume(vol) {
+			blocks = append(blocks, vol)
+		}
+	}
+	sort.SliceStable(blocks, func(i, j int) bool {
+		if left, right := hasVolumeSourceID(blocks[i]), hasVolumeSourceID(blocks[j]); left != right {
+			return left
+		}
+		return volumeBlockSpecificity(blocks[i]) > volumeBlockSpecificity(blocks[j])
+	})
+
+	used := make(map[string]bool, len(created))
+	for _, vol := range blocks {
+		var id string
+		for _, volume := range created {
+			if !used[volume.ID] && isCreatedFromVolumeBlock(volume, vol) {
+				id = volume.ID
+				break
+			}
+		}
+		if id == "" {
+			return fmt.Errorf("cannot find the volume created from the %s volume block with boot index %v among the %d created volumes",
+				vol["source"], vol["boot_index"], len(created))
+		}
+		used[id] = true
+		vol["id"] = id
+	}
+
+	return d.Set("volume", schema.NewSet(volumeUniqueID, requested))
+}
+
+// isCreatedFromVolumeBlock checks if a volume created together with the instance matches the volume block it was requested by.
+// The API reports no boot index of the volume, a boot volume is told apart by being bootable as created from an image or a snapshot.
+func isCreatedFromVolumeBlock(volume volumes.Volume, vol map[string]interface{}) bool {
+	switch types.VolumeSource(vol["source"].(string)) {
+	case types.Image:
+		if imageID, _ := vol["image_id"].(string); volume.VolumeImageMetadata.ImageID != imageID {
+			return false
+		}
+	case types.Snapshot:
+		if snapshotID, _ := vol["snapshot_id"].(string); volume.SnapshotID != snapshotID {
+			return false
+		}
+	case types.NewVolume:
+		if volume.Bootable || volume.VolumeImageMetadata.ImageID != "" || volume.SnapshotID != "" {
+			return false
+		}
+	case volumeSourceAppTemplate:
+		// the volume is created from the image of the template, which the block doesn't name
+		if !volume.Bootable || volume.SnapshotID != "" {
+			return false
+		}
+	}
+	if size, _ := vol["size"].(int); size > 0 && volume.Size != size {
+		return false
+	}
+	if typeName, _ := vol["type_name"].(string); typeName != "" && string(volume.VolumeType) != typeName {
+		return false
+	}
+	if name, _ := vol["name"].(string); name != "" && volume.Name != name {
+		return false
+	}
+
+	return true
+}
+
+// hasVolumeSourceID checks if a volume block names the image or the snapshot it is created from.
+func hasVolumeSourceID(vol map[string]interface{}) bool {
+	imageID, _ := vol["image_id"].(string)
+	snapshotID, _ := vol["snapshot_id"].(string)
+
+	return imageID != "" || snapshotID != ""
+}
+
+// volumeBlockSpecificity counts the optional attributes a volume block created together with the instance sets.
+func volumeBlockSpecificity(vol map[string]interface{}) int {
+	var count int
+	if size, _ := vol["size"].(int); size > 0 {
+		count++
+	}
+	if typeName, _ := vol["type_name"].(string); typeName != "" {
+		count++
+	}
+	if name, _ := vol["name"].(string); name != "" {
+		count++
+	}
+
+	return count
+}
+
+// createdVolumesHashes returns the set hashes of the volumes created together with the instance.
+func createdVolumesHashes(volumes []interface{}) map[int]bool {
+	result := make(map[int]bool)
+	for _, volume := range volumes {
+		vol := volume.(map[string]interface{})
+		if !isExistingVolume(vol) {
+			result[volumeUniqueID(vol)] = true
+		}
+	}
+	return result
+}
+
 // extractKeyValue takes a slice of metadata interfaces and converts it into an instances.MetadataSetOpts structure.
 func extractKeyValue(metadata []interface{}) (instances.MetadataSetOpts, error) {
 	metaData := make([]instances.MetadataOpts, len(metadata))
@@ -179,13 +314,71 @@ func extractKeyValue(metadata []interface{}) (instances.MetadataSetOpts, error)
 }
 
 // volumeUniqueID generates a unique ID for a volume based on its volume_id attribute.
+// Volumes created together with the instance have no volume_id in the configuration and are identified
+// by the attributes they are created with instead.
 func volumeUniqueID(i interface{}) int {
 	e := i.(map[string]interface{})
 	h := md5.New()
-	io.WriteString(h, e["volume_id"].(string))
+	if isExistingVolume(e) {
+		io.WriteString(h, e["volume_id"].(string))
+	} else {
+		fmt.Fprintf(h, "%v-%v-%v-%v-%v-%v-%v", e["source"], e["name"], e["boot_index"], e["image_id"], e["snapshot_id"], e["type_name"], e["size"])
+		if appTemplateID, _ := e["apptemplate_id"].(string); appTemplateID != "" {
+			io.WriteString(h, "-"+appTemplateID)
+		}
+	}
 	return int(binary.BigEndian.Uint64(h.Sum(nil)))
 }
 
+// matchInstanceInterfaces matches the IP assignments of the instance interfaces to the interface blocks they were created by,
+// each block at most once. Blocks are matched by port or IP address first, then by subnet, and only the blocks left
+// are matched network-wide, to an interface in their network or to an external one, so an interface attached
+// by edgecenter_instance_interface doesn't take the block of the instance's own interface.
+// The result holds the index of the block per interface and IP assignment, -1 where no block matches.
+func matchInstanceInterfaces(ifs []instances.Interface, blocks []instances.InterfaceOpts) [][]int {
+	matches := make([][]int, len(ifs))
+	for i, iFace := range ifs {
+		matches[i] = make([]int, len(iFace.IPAssignments))
+		for n := range matches[i] {
+			matches[i][n] = -1
+		}
+	}
+
+	passes := []func(block instances.InterfaceOpts, iFace instances.Interface, assignment instances.PortIP) bool{
+		func(block instances.InterfaceOpts, iFace instances.Interface, assignment instances.PortIP) bool {
+			return block.PortID != "" && block.PortID == iFace.PortID ||
+				block.IPAddress != "" && block.IPAddress == assignment.IPAddress.String()
+		},
+		func(block instances.InterfaceOpts, _ instances.Interface, assignment instances.PortIP) bool {
+			return block.SubnetID != "" && block.SubnetID == assignment.SubnetID
+		},
+		func(block instances.InterfaceOpts, iFace instances.Interface, _ instances.PortIP) bool {
+			return block.Type == types.ExternalInterfaceType && iFace.NetworkDetails.External ||
+				block.Type == types.AnySubnetInterfaceType && block.NetworkID == iFace.NetworkID
+		},
+	}
+
+	used := make([]bool, len(blocks))
+	for _, matchBlock := range passes {
+		for i, iFace := range ifs {
+			for n, assignment := range iFace.IPAssignments {
+				if matches[i][n] >= 0 {
+					continue
+				}
+				for b, block := range blocks {
+					if !used[b] && matchBlock(block, iFace, assignment) {
+						matches[i][n] = b
+						used[b] = true
+						break
+					}
+				}
+			}
+		}
+	}
+
+	return matches
+}
+
 // isInterfaceAttached checks if an interface is attached to a list of instances.Interface objects based on the subnet ID or external interface type.
 func isInterfaceAttached(ifs []instances.Interface, ifs2 map[string]interface{}) bool {
 	subnetID, _ := ifs2["subnet_id"].(string)
@@ -214,12 +407,12 @@ func isInterfaceAttached(ifs []instances.Interface, ifs2 map[string]interface{})
 	return false
 }
 
-// extractVolumesMap takes a slice of volume interfaces and converts it into a slice of instances.CreateVolumeOpts.
-func extractVolumesMap(volumes []interface{}) ([]instances.CreateVolumeOpts, error) {
-	vols := make([]instances.CreateVolumeOpts, len(volumes))
+// extractVolumesMap takes a slice of volume interfaces and converts it into a slice of instanceVolumeOpts.
+func extractVolumesMap(volumes []interface{}) ([]instanceVolumeOpts, error) {
+	vols := make([]instanceVolumeOpts, len(volumes))
 	for i, volume := range volumes {
 		vol := volume.(map[string]interface{})
-		var V instances.CreateVolumeOpts
+		var V instanceVolumeOpts
 		err := MapStructureDecoder(&V, &vol, instanceDecoderConfig)
 		if err != nil {
 			return nil, err
@@ -255,8 +448,7 @@ func ServerV2StateRefreshFunc(client *edgecloud.ServiceClient, instanceID string
 	return func() (interface{}, string, error) {
 		s, err := instances.Get(client, instanceID).Extract()
 		if err != nil {
-			var errDefault404 edgecloud.Default404Error
-			if errors.As(err, &errDefault404) {
+			if isNotFound(err) {
 				return s, "DELETED", nil
 			}
 			return nil, "", err
@@ -266,6 +458,203 @@ func ServerV2StateRefreshFunc(client *edgecloud.ServiceClient, instanceID string
 	}
 }
 
+// instanceTaskStateRefreshFunc returns a StateRefreshFunc reporting the task_state of an instance while an action
+// is in progress and its vm_state otherwise, as a reboot runs with vm_state staying active.
+func instanceTaskStateRefreshFunc(client *edgecloud.ServiceClient, instanceID string) retry.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		s, err := instances.Get(client, instanceID).Extract()
+		if err != nil {
+			if isNotFound(err) {
+				return s, "DELETED", nil
+			}
+			return nil, "", err
+		}
+		if s.TaskState != nil && *s.TaskState != "" {
+			return s, strings.ToLower(*s.TaskState), nil
+		}
+
+		return s, strings.ToLower(s.VMState), nil
+	}
+}
+
+// rescueInstance boots the instance from a rescue image, keeping its volumes attached, for example to repair a broken bootloader.
+func rescueInstance(client *edgecloud.ServiceClient, id string) (r tasks.Result) {
+	_, r.Err = client.Post(client.ServiceURL(id, "rescue"), nil, &r.Body, nil)
+	return
+}
+
+// unrescueInstance boots the rescued instance from its own volumes again.
+func unrescueInstance(client *edgecloud.ServiceClient, id string) (r tasks.Result) {
+	_, r.Err = client.Post(client.ServiceURL(id, "unrescue"), nil, &r.Body, nil)
+	return
+}
+
+// instanceAction is an action changing the power state of the instance.
+// The power actions of the SDK answer with the instance, rescue and unrescue answer with tasks.
+type instanceAction struct {
+	name string
+	run  func(client *edgecloud.ServiceClient, id string) edgecloud.Result
+	// transient are the task states of an action which leaves vm_state as it is, like a reboot.
+	transient []string
+}
+
+// instancePowerAction wraps a power action of the SDK.
+func instancePowerAction(name string, run func(client *edgecloud.ServiceClient, id string) instances.UpdateResult, transient ...string) instanceAction {
+	return instanceAction{
+		name: name,
+		run: func(client *edgecloud.ServiceClient, id string) edgecloud.Result {
+			return run(client, id).Result
+		},
+		transient: transient,
+	}
+}
+
+// instanceTaskAction wraps an action answering with tasks.
+func instanceTaskAction(name string, run func(client *edgecloud.ServiceClient, id string) tasks.Result) instanceAction {
+	return instanceAction{
+		name: name,
+		run: func(client *edgecloud.ServiceClient, id string) edgecloud.Result {
+			return run(client, id).Result
+		},
+	}
+}
+
+var (
+	instanceActionStart      = instancePowerAction("start", instances.Start)
+	instanceActionStop       = instancePowerAction("stop", instances.Stop)
+	instanceActionSuspend    = instancePowerAction("suspend", instances.Suspend)
+	instanceActionResume     = instancePowerAction("resume", instances.Resume)
+	instanceActionReboot     = instancePowerAction("reboot", instances.Reboot, "rebooting", "reboot_pending", "reboot_started")
+	instanceActionPowerCycle = instancePowerAction("powercycle", instances.PowerCycle,
+		"rebooting_hard", "reboot_pending_hard", "reboot_started_hard")
+	instanceActionRescue   = instanceTaskAction("rescue", rescueInstance)
+	instanceActionUnrescue = instanceTaskAction("unrescue", unrescueInstance)
+)
+
+// instanceStateTransitions lists the vm_state changes of an instance and the actions performing them.
+// Any other change is rejected at plan time.
+var instanceStateTransitions = []struct {
+	from, to string
+	action   instanceAction
+}{
+	{from: InstanceVMStateStopped, to: InstanceVMStateActive, action: instanceActionStart},
+	{from: InstanceVMStateActive, to: InstanceVMStateStopped, action: instanceActionStop},
+	{from: InstanceVMStateActive, to: InstanceVMStateSuspended, action: instanceActionSuspend},
+	{from: InstanceVMStateSuspended, to: InstanceVMStateActive, action: instanceActionResume},
+	{from: InstanceVMStateActive, to: InstanceVMStateRescued, action: instanceActionRescue},
+	{from: InstanceVMStateRescued, to: InstanceVMStateActive, action: instanceActionUnrescue},
+}
+
+// instanceStateAction returns the action which moves an instance from the old vm_state to the new one,
+// or nil when the states are the same.
+func instanceStateAction(oldState, newState string) (*instanceAction, error) {
+	oldState, newState = strings.ToLower(oldState), strings.ToLower(newState)
+	if oldState == newState {
+		return nil, nil
+	}
+
+	supported := make([]string, 0, len(instanceStateTransitions))
+	for _, transition := range instanceStateTransitions {
+		if transition.from == oldState && transition.to == newState {
+			return &transition.action, nil
+		}
+		supported = append(supported, transition.from+" to "+transition.to)
+	}
+
+	return nil, fmt.Errorf("cannot change vm_state of the instance from %s to %s, the supported changes are from %s",
+		oldState, newState, strings.Join(supported, ", "))
+}
+
+// runInstanceAction performs the action on the instance and waits for the tasks the API returns.
+// Without tasks, an action which keeps vm_state is first waited for to start, so that a reboot is not
+// taken for done before it begins. Then the instance has to reach vmState with no action in progress.
+func runInstanceAction(ctx context.Context, client *edgecloud.ServiceClient, instanceID string, action instanceAction, vmState string, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	log.Printf("[DEBUG] Instance %s action %s", instanceID, action.name)
+	var results tasks.TaskResults
+	if err := action.run(client, instanceID).ExtractInto(&results); err != nil {
+		return fmt.Errorf("cannot %s instance %s: %w", action.name, instanceID, err)
+	}
+
+	vmState = strings.ToLower(vmState)
+	refresh := instanceTaskStateRefreshFunc(client, instanceID)
+	for _, taskID := range results.Tasks {
+		log.Printf("[DEBUG] Instance %s action %s task id (%s)", instanceID, action.name, taskID)
+		if err := waitTask(ctx, client, taskID, timeout); err != nil {
+			return fmt.Errorf("cannot %s instance %s: %w", action.name, instanceID, err)
+		}
+	}
+
+	if len(results.Tasks) == 0 && len(action.transient) > 0 {
+		startConf := &retry.StateChangeConf{
+			Pending:      []string{vmState},
+			Target:       action.transient,
+			Refresh:      refresh,
+			Timeout:      timeout,
+			PollInterval: time.Second,
+		}
+		if _, err := startConf.WaitForStateContext(ctx); err != nil {
+			return fmt.Errorf("error waiting for instance (%s) to start the %s: %w", instanceID, action.name, err)
+		}
+	}
+
+	stateConf := &retry.StateChangeConf{
+		Target:     []string{vmState},
+		Refresh:    refresh,
+		Timeout:    timeout,
+		Delay:      10 * time.Second,
+		MinTimeout: 3 * time.Second,
+	}
+	if _, err := stateConf.WaitForStateContext(ctx); err != nil {
+		return fmt.Errorf("error waiting for instance (%s) to become %s: %w", instanceID, vmState, err)
+	}
+
+	return nil
+}
+
+// userDataHeaders are the starts of the user data documents cloud-init recognizes, the gzip magic number included.
+var userDataHeaders = [][]byte{
+	{0x1f, 0x8b},
+	[]byte("#cloud-config"),
+	[]byte("#!"),
+	[]byte("#include"),
+	[]byte("#cloud-boothook"),
+	[]byte("#part-handler"),
+	[]byte("#upstart-job"),
+	[]byte("Content-Type:"),
+}
+
+// encodeUserData base64-encodes the user data of an instance, unless it is encoded already,
+// e.g. by edgecenter_cloudinit_config with base64_encode: a base64 text which decodes to a gzip
+// or cloud-init document is passed as is.
+func encodeUserData(userData string) string {
+	if decoded, err := base64.StdEncoding.DecodeString(userData); err == nil {
+		for _, header := range userDataHeaders {
+			if bytes.HasPrefix(decoded, header) {
+				return userData
+			}
+		}
+	}
+
+	return base64.StdEncoding.EncodeToString([]byte(userData))
+}
+
+// validateUserData checks the user data of an instance, encoded as it is sent, against the platform limit.
+func validateUserData(v interface{}, path cty.Path) diag.Diagnostics {
+	return validateEncodedUserData(encodeUserData(v.(string)), path)
+}
+
+// validateEncodedUserData checks user data sent as it is, like that of baremetal servers, against the platform limit.
+func validateEncodedUserData(v interface{}, _ cty.Path) diag.Diagnostics {
+	if size := len(v.(string)); size > UserDataMaxSize {
+		return diag.Errorf("the base64-encoded user data is %d bytes, which exceeds the limit of %d bytes", size, UserDataMaxSize)
+	}
+
+	return nil
+}
+
 // findInstancePort searches for the instance port with the specified portID in the given list of instance ports.
 func findInstancePort(portID string, ports []instances.InstancePorts) (instances.InstancePorts, error) {
 	for _, port := range ports {
@@ -315,8 +704,8 @@ func getMapDifference(iMapOld, iMapNew map[string]interface{}, uncheckedKeys []s
 	return differentFields
 }
 
-// detachInterfaceFromInstance detaches interface from an instance.
-func detachInterfaceFromInstance(client *edgecloud.ServiceClient, instanceID string, iface map[string]interface{}) error {
+// detachInterfaceFromInstance detaches interface from an instance, waiting for the task up to timeout.
+func detachInterfaceFromInstance(ctx context.Context, client *edgecloud.ServiceClient, instanceID string, iface map[string]interface{}, timeout time.Duration) error {
 	var opts instances.InterfaceOpts
 	opts.PortID = iface["port_id"].(string)
 	opts.IPAddress = iface["ip_address"].(string)
@@ -327,7 +716,7 @@ func detachInterfaceFromInstance(client *edgecloud.ServiceClient, instanceID str
 		return err
 	}
 
-	err = tasks.WaitTaskAndProcessResult(client, results.Tasks[0], true, InstanceCreatingTimeout, func(task tasks.TaskID) error {
+	err = waitTaskAndProcessResult(ctx, client, results.Tasks[0], timeout, func(task tasks.TaskID) error {
 		if taskInfo, err := tasks.Get(client, string(task)).Extract(); err != nil {
 			return fmt.Errorf("cannot get task with ID: %s. Error: %w, task: %+v", task, err, taskInfo)
 		}
@@ -340,8 +729,8 @@ func detachInterfaceFromInstance(client *edgecloud.ServiceClient, instanceID str
 	return nil
 }
 
-// attachInterfaceToInstance attach interface to instance.
-func attachInterfaceToInstance(instanceClient *edgecloud.ServiceClient, instanceID string, iface map[string]interface{}) error {
+// attachInterfaceToInstance attach interface to instance, waiting for the task up to timeout.
+func attachInterfaceToInstance(ctx context.Context, instanceClient *edgecloud.ServiceClient, instanceID string, iface map[string]interface{}, timeout time.Duration) error {
 	iType := types.InterfaceType(iface["type"].(string))
 	opts := instances.InterfaceInstanceCreateOpts{
 		InterfaceOpts: instances.InterfaceOpts{Type: iType},
@@ -363,7 +752,7 @@ func attachInterfaceToInstance(instanceClient *edgecloud.ServiceClient, instance
 		return fmt.Errorf("cannot attach interface: %s. Error: %w", iType, err)
 	}
 
-	err = tasks.WaitTaskAndProcessResult(instanceClient, results.Tasks[0], true, InstanceCreatingTimeout, func(task tasks.TaskID) error {
+	err = waitTaskAndProcessResult(ctx, instanceClient, results.Tasks[0], timeout, func(task tasks.TaskID) error {
 		taskInfo, err := tasks.Get(instanceClient, string(task)).Extract()
 		if err != nil {
 			return fmt.Errorf("cannot get task with ID: %s. Error: %w, task: %+v", task, err, taskInfo)
@@ -385,15 +774,15 @@ func attachInterfaceToInstance(instanceClient *edgecloud.ServiceClient, instance
 	return nil
 }
 
-// deleteServerGroup removes a server group from an instance.
-func deleteServerGroup(sgClient, instanceClient *edgecloud.ServiceClient, instanceID, sgID string) error {
+// deleteServerGroup removes a server group from an instance, waiting for the task up to timeout.
+func deleteServerGroup(ctx context.Context, sgClient, instanceClient *edgecloud.ServiceClient, instanceID, sgID string, timeout time.Duration) error {
 	log.Printf("[DEBUG] remove server group from instance: %s", instanceID)
 	results, err := instances.RemoveServerGroup(instanceClient, instanceID).Extract()
 	if err != nil {
 		return fmt.Errorf("failed to remove server group %s from instance %s: %w", sgID, instanceID, err)
 	}
 
-	err = tasks.WaitTaskAndProcessResult(sgClient, results.Tasks[0], true, InstanceCreatingTimeout, func(task tasks.TaskID) error {
+	err = waitTaskAndProcessResult(ctx, sgClient, results.Tasks[0], timeout, func(task tasks.TaskID) error {
 		sgInfo, err := servergroups.Get(sgClient, sgID).Extract()
 		if err != nil {
 			return fmt.Errorf("failed to get server group %s: %w", sgID, err)
@@ -413,15 +802,15 @@ func deleteServerGroup(sgClient, instanceClient *edgecloud.ServiceClient, instan
 	return nil
 }
 
-// addServerGroup adds a server group to an instance.
-func addServerGroup(sgClient, instanceClient *edgecloud.ServiceClient, instanceID, sgID string) error {
+// addServerGroup adds a server group to an instance, waiting for the task up to timeout.
+func addServerGroup(ctx context.Context, sgClient, instanceClient *edgecloud.ServiceClient, instanceID, sgID string, timeout time.Duration) error {
 	log.Printf("[DEBUG] add server group to instance: %s", instanceID)
 	results, err := instances.AddServerGroup(instanceClient, instanceID, instances.ServerGroupOpts{ServerGroupID: sgID}).Extract()
 	if err != nil {
 		return fmt.Errorf("failed to add server group %s to instance %s: %w", sgID, instanceID, err)
 	}
 
-	err = tasks.WaitTaskAndProcessResult(sgClient, results.Tasks[0], true, InstanceCreatingTimeout, func(task tasks.TaskID) error {
+	err = waitTaskAndProcessResult(ctx, sgClient, results.Tasks[0], timeout, func(task tasks.TaskID) error {
 		sgInfo, err := servergroups.Get(sgClient, sgID).Extract()
 		if err != nil {
 			return fmt.Errorf("cannot get server group with ID: %s. Error: %w", sgID, err)
@@ -525,3 +914,27 @@ func getSecurityGroupsDifference(sl1, sl2 []edgecloud.ItemID) (diff []edgecloud.
 
 	return diff
 }
+
+// instanceAddressesList groups the addresses of an instance per network, ordered by the network name.
+func instanceAddressesList(addresses map[string][]instances.InstanceAddress) []map[string][]map[string]string {
+	networks := make([]string, 0, len(addresses))
+	for network := range addresses {
+		networks = append(networks, network)
+	}
+	sort.Strings(networks)
+
+	result := make([]map[string][]map[string]string, 0, len(networks))
+	for _, network := range networks {
+		data := addresses[network]
+		netd := make([]map[string]string, len(data))
+		for i, iaddr := range data {
+			ndata := make(map[string]string, 2)
+			ndata["type"] = iaddr.Type.String()
+			ndata["addr"] = iaddr.Address.String()
+			netd[i] = ndata
+		}
+		result = append(result, map[string][]map[string]string{"net": netd})
+	}
+
+	return result
+}
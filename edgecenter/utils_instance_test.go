@@ -0,0 +1,318 @@
+package edgecenter
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"net"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/Edge-Center/edgecentercloud-go/edgecenter/instance/v1/instances"
+	"github.com/Edge-Center/edgecentercloud-go/edgecenter/instance/v1/types"
+	"github.com/Edge-Center/edgecentercloud-go/edgecenter/volume/v1/volumes"
+)
+
+func TestSetCreatedVolumeIDs(t *testing.T) {
+	boot := volumes.Volume{ID: "boot", Size: 5, Bootable: true, VolumeType: "standard"}
+	boot.VolumeImageMetadata.ImageID = "image-1"
+	fromSnapshot := volumes.Volume{ID: "restored", Size: 20, Bootable: true, VolumeType: "standard", SnapshotID: "snapshot-1"}
+	data := volumes.Volume{ID: "data", Name: "data", Size: 10, VolumeType: "ssd_hiiops"}
+	logs := volumes.Volume{ID: "logs", Name: "logs", Size: 10, VolumeType: "ssd_hiiops"}
+	scratch := volumes.Volume{ID: "scratch", Name: "volume", Size: 1, VolumeType: "standard"}
+
+	requested := []interface{}{
+		map[string]interface{}{"source": "image", "image_id": "image-1", "size": 5, "boot_index": 0},
+		map[string]interface{}{"source": "snapshot", "snapshot_id": "snapshot-1", "boot_index": 1},
+		// the blank blocks of the same size and type are told apart by name, the unnamed one takes what's left
+		map[string]interface{}{"source": "new-volume", "boot_index": 2},
+		map[string]interface{}{"source": "new-volume", "name": "logs", "size": 10, "type_name": "ssd_hiiops", "boot_index": 3},
+		map[string]interface{}{"source": "new-volume", "name": "data", "size": 10, "type_name": "ssd_hiiops", "boot_index": 4},
+		map[string]interface{}{"source": "existing-volume", "volume_id": "attached", "boot_index": 5},
+	}
+	want := []string{"boot", "restored", "scratch", "logs", "data", ""}
+
+	tests := []struct {
+		name    string
+		created []volumes.Volume
+	}{
+		{name: "requested order", created: []volumes.Volume{boot, fromSnapshot, scratch, logs, data}},
+		{name: "reversed order", created: []volumes.Volume{data, logs, scratch, fromSnapshot, boot}},
+		{name: "blank volumes first", created: []volumes.Volume{scratch, data, logs, boot, fromSnapshot}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			blocks := make([]interface{}, len(requested))
+			for i, raw := range requested {
+				block := make(map[string]interface{})
+				for k, v := range raw.(map[string]interface{}) {
+					block[k] = v
+				}
+				blocks[i] = block
+			}
+
+			d := schema.TestResourceDataRaw(t, resourceInstance().Schema, map[string]interface{}{})
+			if err := setCreatedVolumeIDs(d, blocks, tt.created); err != nil {
+				t.Fatal(err)
+			}
+			for i, block := range blocks {
+				if id, _ := block.(map[string]interface{})["id"].(string); id != want[i] {
+					t.Errorf("volume block %d got id %q, want %q", i, id, want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestSetCreatedVolumeIDsUnmatched(t *testing.T) {
+	blank := volumes.Volume{ID: "blank", Size: 10, VolumeType: "standard"}
+	requested := []interface{}{
+		map[string]interface{}{"source": "image", "image_id": "image-1", "size": 10, "boot_index": 0},
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceInstance().Schema, map[string]interface{}{})
+	if err := setCreatedVolumeIDs(d, requested, []volumes.Volume{blank}); err == nil {
+		t.Fatal("expected an error for a volume block without its created volume")
+	}
+}
+
+func TestMatchInstanceInterfaces(t *testing.T) {
+	external := func(portID, ip string) instances.Interface {
+		iFace := instances.Interface{PortID: portID, NetworkID: "external-net"}
+		iFace.NetworkDetails.External = true
+		iFace.IPAssignments = []instances.PortIP{{IPAddress: net.ParseIP(ip), SubnetID: "external-subnet"}}
+		return iFace
+	}
+	private := instances.Interface{
+		PortID:        "private-port",
+		NetworkID:     "private-net",
+		IPAssignments: []instances.PortIP{{IPAddress: net.ParseIP("192.168.0.5"), SubnetID: "private-subnet"}},
+	}
+
+	tests := []struct {
+		name   string
+		ifs    []instances.Interface
+		blocks []instances.InterfaceOpts
+		want   [][]int
+	}{
+		{
+			name:   "attached external interface is left out",
+			ifs:    []instances.Interface{external("own-port", "203.0.113.10"), external("attached-port", "203.0.113.11")},
+			blocks: []instances.InterfaceOpts{{Type: types.ExternalInterfaceType}},
+			want:   [][]int{{0}, {-1}},
+		},
+		{
+			name:   "port is preferred over the external fallback",
+			ifs:    []instances.Interface{external("attached-port", "203.0.113.11"), external("own-port", "203.0.113.10")},
+			blocks: []instances.InterfaceOpts{{Type: types.ExternalInterfaceType, PortID: "own-port"}},
+			want:   [][]int{{-1}, {0}},
+		},
+		{
+			name:   "ip address is preferred over the network fallback",
+			ifs:    []instances.Interface{external("first-port", "203.0.113.10"), external("second-port", "203.0.113.11")},
+			blocks: []instances.InterfaceOpts{{Type: types.ExternalInterfaceType}, {Type: types.ExternalInterfaceType, IPAddress: "203.0.113.10"}},
+			want:   [][]int{{1}, {0}},
+		},
+		{
+			name: "subnet and any subnet blocks",
+			ifs:  []instances.Interface{private, external("own-port", "203.0.113.10")},
+			blocks: []instances.InterfaceOpts{
+				{Type: types.ExternalInterfaceType},
+				{Type: types.AnySubnetInterfaceType, NetworkID: "private-net"},
+				{Type: types.SubnetInterfaceType, NetworkID: "private-net", SubnetID: "private-subnet"},
+			},
+			want: [][]int{{2}, {0}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := matchInstanceInterfaces(tt.ifs, tt.blocks); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("matches = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInstanceStateAction(t *testing.T) {
+	tests := []struct {
+		from, to string
+		want     string
+		wantErr  bool
+	}{
+		{from: "active", to: "stopped", want: "stop"},
+		{from: "Stopped", to: "ACTIVE", want: "start"},
+		{from: "active", to: "suspended", want: "suspend"},
+		{from: "suspended", to: "active", want: "resume"},
+		{from: "active", to: "Active"},
+		{from: "suspended", to: "stopped", wantErr: true},
+		{from: "stopped", to: "suspended", wantErr: true},
+		{from: "active", to: "rescued", want: "rescue"},
+		{from: "rescued", to: "active", want: "unrescue"},
+		{from: "stopped", to: "rescued", wantErr: true},
+		{from: "rescued", to: "suspended", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.from+" to "+tt.to, func(t *testing.T) {
+			action, err := instanceStateAction(tt.from, tt.to)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, want error %v", err, tt.wantErr)
+			}
+			var got string
+			if action != nil {
+				got = action.name
+			}
+			if got != tt.want {
+				t.Errorf("action = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEncodeUserData(t *testing.T) {
+	var compressed bytes.Buffer
+	w := gzip.NewWriter(&compressed)
+	w.Write([]byte("#cloud-config\n"))
+	w.Close()
+	gzipped := base64.StdEncoding.EncodeToString(compressed.Bytes())
+
+	tests := []struct {
+		name     string
+		userData string
+		want     string
+	}{
+		{
+			name:     "plain cloud-config is encoded",
+			userData: "#cloud-config\npackages: [nginx]\n",
+			want:     base64.StdEncoding.EncodeToString([]byte("#cloud-config\npackages: [nginx]\n")),
+		},
+		{
+			name:     "encoded cloud-config is passed as is",
+			userData: base64.StdEncoding.EncodeToString([]byte("#cloud-config\npackages: [nginx]\n")),
+			want:     base64.StdEncoding.EncodeToString([]byte("#cloud-config\npackages: [nginx]\n")),
+		},
+		{
+			name:     "encoded script is passed as is",
+			userData: base64.StdEncoding.EncodeToString([]byte("#!/bin/sh\necho ok\n")),
+			want:     base64.StdEncoding.EncodeToString([]byte("#!/bin/sh\necho ok\n")),
+		},
+		{
+			name:     "encoded multipart document is passed as is",
+			userData: base64.StdEncoding.EncodeToString([]byte("Content-Type: multipart/mixed; boundary=\"b\"\r\n")),
+			want:     base64.StdEncoding.EncodeToString([]byte("Content-Type: multipart/mixed; boundary=\"b\"\r\n")),
+		},
+		{
+			name:     "encoded gzip document is passed as is",
+			userData: gzipped,
+			want:     gzipped,
+		},
+		{
+			name:     "plain text which happens to be base64 is encoded",
+			userData: "dGVzdA==",
+			want:     base64.StdEncoding.EncodeToString([]byte("dGVzdA==")),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := encodeUserData(tt.userData); got != tt.want {
+				t.Errorf("encodeUserData() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateUserData(t *testing.T) {
+	// plain user data grows by a third when encoded, encoded user data is checked as it is
+	plain := strings.Repeat("a", UserDataMaxSize/4*3+3)
+	encoded := base64.StdEncoding.EncodeToString([]byte("#cloud-config\n" + strings.Repeat("a", UserDataMaxSize/4*3-20)))
+
+	if diags := validateUserData(plain, nil); !diags.HasError() {
+		t.Error("expected plain user data over the limit once encoded to be rejected")
+	}
+	if diags := validateUserData(encoded, nil); diags.HasError() {
+		t.Errorf("expected encoded user data within the limit to pass, got %v", diags)
+	}
+	if diags := validateEncodedUserData(strings.Repeat("a", UserDataMaxSize+1), nil); !diags.HasError() {
+		t.Error("expected baremetal user data over the limit to be rejected")
+	}
+}
+
+func TestSetCreatedVolumeIDsAppTemplate(t *testing.T) {
+	fromImage := volumes.Volume{ID: "from-image", Size: 20, Bootable: true, VolumeType: "standard"}
+	fromImage.VolumeImageMetadata.ImageID = "image-1"
+	fromTemplate := volumes.Volume{ID: "from-template", Size: 20, Bootable: true, VolumeType: "standard"}
+	fromTemplate.VolumeImageMetadata.ImageID = "image-of-gitlab"
+
+	// the template block sets more attributes, still the image block takes its volume first
+	requested := []interface{}{
+		map[string]interface{}{"source": "apptemplate", "apptemplate_id": "gitlab", "size": 20, "type_name": "standard", "boot_index": 0},
+		map[string]interface{}{"source": "image", "image_id": "image-1", "boot_index": 1},
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceInstance().Schema, map[string]interface{}{})
+	if err := setCreatedVolumeIDs(d, requested, []volumes.Volume{fromImage, fromTemplate}); err != nil {
+		t.Fatal(err)
+	}
+	for i, want := range []string{"from-template", "from-image"} {
+		if id := requested[i].(map[string]interface{})["id"]; id != want {
+			t.Errorf("volume block %d got id %v, want %s", i, id, want)
+		}
+	}
+}
+
+func TestInstanceCreateOptsVolumes(t *testing.T) {
+	opts := instanceCreateOpts{
+		CreateOpts: instances.CreateOpts{
+			Flavor:     "g1-standard-1-2",
+			Names:      []string{"gitlab"},
+			Interfaces: []instances.InterfaceInstanceCreateOpts{{InterfaceOpts: instances.InterfaceOpts{Type: types.ExternalInterfaceType}}},
+		},
+	}
+	volumeBlocks := []interface{}{
+		map[string]interface{}{"source": "apptemplate", "apptemplate_id": "gitlab", "size": 20, "boot_index": 0, "id": ""},
+		map[string]interface{}{"source": "new-volume", "size": 10, "boot_index": 1},
+	}
+	vols, err := extractVolumesMap(volumeBlocks)
+	if err != nil {
+		t.Fatal(err)
+	}
+	opts.Volumes = vols
+
+	body, err := opts.ToInstanceCreateMap()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []interface{}{
+		map[string]interface{}{"source": "apptemplate", "apptemplate_id": "gitlab", "size": float64(20), "boot_index": float64(0)},
+		map[string]interface{}{"source": "new-volume", "size": float64(10), "boot_index": float64(1)},
+	}
+	if !reflect.DeepEqual(body["volumes"], want) {
+		t.Errorf("volumes = %v, want %v", body["volumes"], want)
+	}
+	if body["flavor"] != "g1-standard-1-2" {
+		t.Errorf("flavor = %v, want the rest of the body built", body["flavor"])
+	}
+
+	invalid := []map[string]interface{}{
+		{"source": "apptemplate", "boot_index": 0},
+		{"source": "apptemplate", "apptemplate_id": "gitlab", "image_id": "b5b4d65d-945f-4b98-ab6f-332319c724ef"},
+		{"source": "image", "apptemplate_id": "gitlab", "image_id": "b5b4d65d-945f-4b98-ab6f-332319c724ef", "size": 10},
+		{"source": "image", "size": 10},
+	}
+	for _, block := range invalid {
+		vols, err := extractVolumesMap([]interface{}{block})
+		if err != nil {
+			t.Fatal(err)
+		}
+		opts.Volumes = vols
+		if _, err := opts.ToInstanceCreateMap(); err == nil {
+			t.Errorf("expected volume %v to be rejected", block)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package edgecenter
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/binary"
+	"encoding/pem"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/ssh"
+)
+
+const (
+	KeypairAlgorithmED25519 = "ed25519"
+	KeypairAlgorithmRSA     = "rsa"
+
+	keypairRSABits = 4096
+)
+
+// generateKeyPair creates a key pair with the algorithm and returns the public key
+// in the authorized_keys format and the private key in PEM.
+func generateKeyPair(algorithm string) (string, string, error) {
+	var signer interface{}
+	var privateKey *pem.Block
+	switch algorithm {
+	case KeypairAlgorithmED25519:
+		public, private, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return "", "", fmt.Errorf("cannot generate an ed25519 key: %w", err)
+		}
+		signer = public
+		privateKey, err = marshalED25519PrivateKey(public, private)
+		if err != nil {
+			return "", "", err
+		}
+	case KeypairAlgorithmRSA:
+		private, err := rsa.GenerateKey(rand.Reader, keypairRSABits)
+		if err != nil {
+			return "", "", fmt.Errorf("cannot generate an RSA key: %w", err)
+		}
+		signer = &private.PublicKey
+		privateKey = &pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(private)}
+	default:
+		return "", "", fmt.Errorf("unknown key algorithm %q", algorithm)
+	}
+
+	publicKey, err := ssh.NewPublicKey(signer)
+	if err != nil {
+		return "", "", err
+	}
+
+	return strings.TrimSpace(string(ssh.MarshalAuthorizedKey(publicKey))), string(pem.EncodeToMemory(privateKey)), nil
+}
+
+// marshalED25519PrivateKey encodes an unencrypted ed25519 key in the OpenSSH format,
+// the only one OpenSSH reads ed25519 keys in.
+func marshalED25519PrivateKey(public ed25519.PublicKey, private ed25519.PrivateKey) (*pem.Block, error) {
+	checkBytes := make([]byte, 4)
+	if _, err := rand.Read(checkBytes); err != nil {
+		return nil, err
+	}
+	check := binary.BigEndian.Uint32(checkBytes)
+
+	key := struct {
+		Check1  uint32
+		Check2  uint32
+		Keytype string
+		Pub     []byte
+		Priv    []byte
+		Comment string
+		Pad     []byte `ssh:"rest"`
+	}{
+		Check1:  check,
+		Check2:  check,
+		Keytype: ssh.KeyAlgoED25519,
+		Pub:     public,
+		Priv:    private,
+	}
+	// the private section is padded to the cipher block size, 8 for "none"
+	unpadded := len(ssh.Marshal(key))
+	for i := 0; (unpadded+i)%8 != 0; i++ {
+		key.Pad = append(key.Pad, byte(i+1))
+	}
+
+	publicKey, err := ssh.NewPublicKey(public)
+	if err != nil {
+		return nil, err
+	}
+	envelope := struct {
+		CipherName   string
+		KdfName      string
+		KdfOpts      string
+		NumKeys      uint32
+		PubKey       []byte
+		PrivKeyBlock []byte
+	}{
+		CipherName:   "none",
+		KdfName:      "none",
+		NumKeys:      1,
+		PubKey:       publicKey.Marshal(),
+		PrivKeyBlock: ssh.Marshal(key),
+	}
+
+	return &pem.Block{
+		Type:  "OPENSSH PRIVATE KEY",
+		Bytes: append([]byte("openssh-key-v1\x00"), ssh.Marshal(envelope)...),
+	}, nil
+}
@@ -0,0 +1,62 @@
+package edgecenter
+
+import (
+	"io"
+	"net/http"
+	"sync"
+)
+
+// concurrencyLimitTransport lets at most cap(slots) requests of a client through at the same time.
+// A slot is held until the response body is closed, as the response is still being read until then.
+type concurrencyLimitTransport struct {
+	next  http.RoundTripper
+	slots chan struct{}
+}
+
+// newConcurrencyLimitTransport wraps next, or http.DefaultTransport when next is nil, with a semaphore of limit slots.
+// A limit of zero or less disables the limit.
+func newConcurrencyLimitTransport(next http.RoundTripper, limit int) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	if limit <= 0 {
+		return next
+	}
+
+	return &concurrencyLimitTransport{next: next, slots: make(chan struct{}, limit)}
+}
+
+func (t *concurrencyLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	select {
+	case t.slots <- struct{}{}:
+	case <-req.Context().Done():
+		return nil, req.Context().Err() //nolint: wrapcheck
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		t.release()
+		return nil, err //nolint: wrapcheck
+	}
+	resp.Body = &releasingBody{ReadCloser: resp.Body, release: t.release}
+
+	return resp, nil
+}
+
+func (t *concurrencyLimitTransport) release() {
+	<-t.slots
+}
+
+// releasingBody frees the slot of its request once, on the first Close.
+type releasingBody struct {
+	io.ReadCloser
+	once    sync.Once
+	release func()
+}
+
+func (b *releasingBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.release)
+
+	return err //nolint: wrapcheck
+}
@@ -3,7 +3,6 @@ package edgecenter
 import (
 	"fmt"
 	"log"
-	"strconv"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -13,26 +12,18 @@ import (
 	typesLb "github.com/Edge-Center/edgecentercloud-go/edgecenter/loadbalancer/v1/types"
 )
 
-// ImportStringParserExtended parses a string containing project ID, region ID, and two other fields,
-// and returns them as separate values along with any error encountered.
-func ImportStringParserExtended(infoStr string) (projectID int, regionID int, id3 string, id4 string, err error) { //nolint: nonamedreturns
+// ImportStringParserExtended parses an import ID of the form "project:region:id3:id4", where project and region
+// are given either by ID or by name, and returns the project ID, the region ID and the two other fields.
+func ImportStringParserExtended(config *Config, infoStr string) (projectID int, regionID int, id3 string, id4 string, err error) { //nolint: nonamedreturns
 	log.Printf("[DEBUG] Input id string: %s", infoStr)
 	infoStrings := strings.Split(infoStr, ":")
 	if len(infoStrings) != 4 {
-		err = fmt.Errorf("failed import: wrong input id: %s", infoStr)
+		err = fmt.Errorf("failed import: wrong input id: %s, expected <project>:<region>:<id>:<id>", infoStr)
 		return
 	}
 
-	id1, id2, id3, id4 := infoStrings[0], infoStrings[1], infoStrings[2], infoStrings[3]
-
-	projectID, err = strconv.Atoi(id1)
-	if err != nil {
-		return
-	}
-	regionID, err = strconv.Atoi(id2)
-	if err != nil {
-		return
-	}
+	id3, id4 = infoStrings[2], infoStrings[3]
+	projectID, regionID, err = parseImportScope(config, infoStrings[0], infoStrings[1])
 
 	return
 }
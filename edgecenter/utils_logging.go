@@ -0,0 +1,167 @@
+package edgecenter
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	// httpLogBodyLimit is how much of a request or response body is traced.
+	httpLogBodyLimit = 16 << 10
+	// httpLogRedacted replaces secrets in traced headers and bodies.
+	httpLogRedacted = "***"
+)
+
+// sensitiveHeaders are never traced with their values.
+var sensitiveHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie", "X-Auth-Token"}
+
+// sensitiveBodyFields matches JSON string fields holding secrets: API tokens, instance and SFTP passwords,
+// private keys of secrets and keypairs and S3 secret keys.
+var sensitiveBodyFields = regexp.MustCompile(
+	`("(?:[a-z_]*password|[a-z_]*token|private_key|secret_key|access|refresh)"\s*:\s*)"(?:[^"\\]|\\.)*"`)
+
+// loggingTransport traces every request and response of one API to its own tflog subsystem at TRACE level,
+// e.g. TF_LOG=TRACE or TF_LOG_PROVIDER_EDGECENTER_CLOUD=TRACE, with secrets redacted.
+// The subsystem logs to the provider logger of each request context.
+type loggingTransport struct {
+	next      http.RoundTripper
+	subsystem string
+	secrets   []string
+}
+
+// newLoggingTransport wraps next, or http.DefaultTransport when next is nil, with request tracing.
+// Secrets are masked wherever they show up in the traced fields.
+// When the subsystem doesn't log at TRACE level next is returned as is, so bodies are never buffered for nothing.
+func newLoggingTransport(next http.RoundTripper, subsystem string, secrets ...string) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	if !httpTraceEnabled(subsystem) {
+		return next
+	}
+
+	return &loggingTransport{next: next, subsystem: subsystem, secrets: secrets}
+}
+
+// httpTraceEnabled reports whether the subsystem logs at TRACE level, resolving its level from the environment
+// the way tflog does: the subsystem variable first, then the provider ones, then TF_LOG.
+func httpTraceEnabled(subsystem string) bool {
+	for _, env := range []string{
+		"TF_LOG_PROVIDER_EDGECENTER_" + strings.ToUpper(subsystem),
+		"TF_LOG_PROVIDER_EDGECENTER",
+		"TF_LOG_PROVIDER",
+	} {
+		if level := os.Getenv(env); level != "" {
+			return strings.EqualFold(level, "TRACE")
+		}
+	}
+	if os.Getenv("TF_ACC_LOG_PATH") != "" {
+		return true
+	}
+	level := os.Getenv("TF_LOG")
+
+	return strings.EqualFold(level, "TRACE") || strings.EqualFold(level, "JSON")
+}
+
+// logContext returns the subsystem logging context of the request.
+func (t *loggingTransport) logContext(req *http.Request) context.Context {
+	ctx := tflog.NewSubsystem(req.Context(), t.subsystem, tflog.WithLevelFromEnv("TF_LOG_PROVIDER_EDGECENTER", t.subsystem))
+	for _, secret := range t.secrets {
+		if secret != "" {
+			ctx = tflog.SubsystemMaskAllFieldValuesStrings(ctx, t.subsystem, secret)
+		}
+	}
+
+	return ctx
+}
+
+func (t *loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := t.logContext(req)
+	fields := map[string]interface{}{
+		"http_method":          req.Method,
+		"http_url":             req.URL.String(),
+		"http_request_headers": RedactHTTPHeaders(req.Header),
+	}
+	if req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			fields["http_request_body"] = RedactHTTPBody(readLogPrefix(body))
+			body.Close()
+		}
+	}
+	tflog.SubsystemTrace(ctx, t.subsystem, "Sending HTTP request", fields)
+
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+	fields = map[string]interface{}{
+		"http_method":   req.Method,
+		"http_url":      req.URL.String(),
+		"http_duration": time.Since(start).String(),
+	}
+	if err != nil {
+		fields["error"] = err.Error()
+		tflog.SubsystemTrace(ctx, t.subsystem, "HTTP request failed", fields)
+		return nil, err //nolint: wrapcheck
+	}
+
+	prefix, rest := peekBody(resp.Body)
+	resp.Body = rest
+	fields["http_status_code"] = resp.StatusCode
+	fields["http_response_headers"] = RedactHTTPHeaders(resp.Header)
+	fields["http_response_body"] = RedactHTTPBody(prefix)
+	tflog.SubsystemTrace(ctx, t.subsystem, "Received HTTP response", fields)
+
+	return resp, nil
+}
+
+// detachedContext keeps the values of a context, the provider logger among them, without its cancellation,
+// like context.WithoutCancel of Go 1.21. It lets clients outliving the configure call log to its logger.
+type detachedContext struct {
+	context.Context //nolint: containedctx
+}
+
+func (detachedContext) Deadline() (time.Time, bool) { return time.Time{}, false }
+func (detachedContext) Done() <-chan struct{}       { return nil }
+func (detachedContext) Err() error                  { return nil }
+
+// readLogPrefix reads at most httpLogBodyLimit bytes of body.
+func readLogPrefix(body io.Reader) []byte {
+	prefix, _ := io.ReadAll(io.LimitReader(body, httpLogBodyLimit))
+	return prefix
+}
+
+// peekBody reads the traced prefix of body and returns a body which still yields the whole content.
+func peekBody(body io.ReadCloser) ([]byte, io.ReadCloser) {
+	prefix := readLogPrefix(body)
+	return prefix, struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(prefix), body), body}
+}
+
+// RedactHTTPHeaders flattens header for tracing with the values of sensitiveHeaders replaced.
+func RedactHTTPHeaders(header http.Header) map[string]string {
+	result := make(map[string]string, len(header))
+	for k, v := range header {
+		result[k] = strings.Join(v, ", ")
+	}
+	for _, k := range sensitiveHeaders {
+		if _, ok := result[k]; ok {
+			result[k] = httpLogRedacted
+		}
+	}
+
+	return result
+}
+
+// RedactHTTPBody returns body for tracing with the values of sensitiveBodyFields replaced.
+func RedactHTTPBody(body []byte) string {
+	return sensitiveBodyFields.ReplaceAllString(string(body), `$1"`+httpLogRedacted+`"`)
+}
@@ -0,0 +1,107 @@
+package edgecenter
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	defaultCredentialsFile    = "~/.edgecenter/credentials"
+	defaultCredentialsProfile = "default"
+)
+
+// credentialsProfileKeys are the provider arguments a profile of the shared credentials file may hold.
+var credentialsProfileKeys = []string{
+	ProviderOptPermanentToken,
+	ProviderOptSingleAPIEndpoint,
+	"edgecenter_cloud_api",
+	"edgecenter_cdn_api",
+	"edgecenter_storage_api",
+	"edgecenter_dns_api",
+	"edgecenter_platform_api",
+}
+
+// credentialsProfile is a named profile of the shared credentials file, keyed by provider argument.
+type credentialsProfile map[string]string
+
+// loadCredentialsProfile reads the profile from the shared credentials file, a YAML map of profile names to settings.
+// Without an explicit profile the "default" one is used if the file defines it, and a missing file is ignored.
+func loadCredentialsProfile(path, profile string) (credentialsProfile, error) {
+	explicit := profile != ""
+	if !explicit {
+		profile = defaultCredentialsProfile
+	}
+
+	if strings.HasPrefix(path, "~/") {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, fmt.Errorf("cannot expand %s: %w", path, err)
+		}
+		path = filepath.Join(home, path[2:])
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) && !explicit {
+			return credentialsProfile{}, nil
+		}
+		return nil, fmt.Errorf("cannot read credentials file %s: %w", path, err)
+	}
+
+	var profiles map[string]credentialsProfile
+	if err := yaml.Unmarshal(data, &profiles); err != nil {
+		return nil, fmt.Errorf("cannot parse credentials file %s: %w", path, err)
+	}
+
+	result, ok := profiles[profile]
+	if !ok {
+		if !explicit {
+			return credentialsProfile{}, nil
+		}
+		names := make([]string, 0, len(profiles))
+		for name := range profiles {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return nil, fmt.Errorf("profile %s not found in %s, available: %s", profile, path, strings.Join(names, ", "))
+	}
+
+	for key := range result {
+		if !isCredentialsProfileKey(key) {
+			return nil, fmt.Errorf("unknown key %s in profile %s of %s, expected one of: %s",
+				key, profile, path, strings.Join(credentialsProfileKeys, ", "))
+		}
+	}
+
+	return result, nil
+}
+
+func isCredentialsProfileKey(key string) bool {
+	for _, k := range credentialsProfileKeys {
+		if k == key {
+			return true
+		}
+	}
+
+	return false
+}
+
+// getString returns the provider argument set in the configuration or in its environment variable,
+// or else the first of its deprecated aliases set so, falling back to the value of the profile.
+func (p credentialsProfile) getString(d *schema.ResourceData, key string, deprecatedKeys ...string) string {
+	for _, k := range append([]string{key}, deprecatedKeys...) {
+		if v := d.Get(k).(string); v != "" {
+			return v
+		}
+	}
+
+	return p[key]
+}
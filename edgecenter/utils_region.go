@@ -1,7 +1,6 @@
 package edgecenter
 
 import (
-	"fmt"
 	"log"
 
 	edgecloud "github.com/Edge-Center/edgecentercloud-go"
@@ -9,25 +8,8 @@ import (
 	"github.com/Edge-Center/edgecentercloud-go/edgecenter/region/v1/regions"
 )
 
-// findRegionByName searches for a region with the specified name in the provided region slice.
-// Returns the region ID if found, otherwise returns an error.
-func findRegionByName(arr []regions.Region, name string) (int, error) {
-	for _, el := range arr {
-		if el.DisplayName == name {
-			return el.ID, nil
-		}
-	}
-	return 0, fmt.Errorf("region with name %s not found", name)
-}
-
-// GetRegion returns a valid region ID for a resource.
-// If the regionID is provided, it will be returned directly.
-// If regionName is provided instead, the function will search for the region by name and return its ID.
-// Returns an error if the region is not found or there is an issue with the client.
-func GetRegion(provider *edgecloud.ProviderClient, regionID int, regionName string) (int, error) {
-	if regionID != 0 {
-		return regionID, nil
-	}
+// listRegions returns the ID and display name of every region available to the provider.
+func listRegions(provider *edgecloud.ProviderClient) ([]namedID, error) {
 	client, err := edgecenter.ClientServiceFromProvider(provider, edgecloud.EndpointOpts{
 		Name:    RegionPoint,
 		Region:  0,
@@ -35,19 +17,25 @@ func GetRegion(provider *edgecloud.ProviderClient, regionID int, regionName stri
 		Version: VersionPointV1,
 	})
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
 
 	rs, err := regions.ListAll(client)
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
 	log.Printf("[DEBUG] Regions: %v", rs)
-	regionID, err = findRegionByName(rs, regionName)
-	if err != nil {
-		return 0, err
+
+	items := make([]namedID, 0, len(rs))
+	for _, r := range rs {
+		items = append(items, namedID{ID: r.ID, Name: r.DisplayName})
 	}
-	log.Printf("[DEBUG] The attempt to get the region is successful: regionID=%d", regionID)
 
-	return regionID, nil
+	return items, nil
+}
+
+// GetRegion returns a valid region ID. If regionID is provided, it is returned directly,
+// otherwise regionName is resolved through the cached region list of the config, like resources do.
+func GetRegion(config *Config, regionID int, regionName string) (int, error) {
+	return config.resolveRegion(regionID, regionName)
 }
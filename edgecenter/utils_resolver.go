@@ -0,0 +1,98 @@
+package edgecenter
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+)
+
+// namedID is a project or a region reduced to what is needed to resolve its name.
+type namedID struct {
+	ID   int
+	Name string
+}
+
+// nameResolver lists objects of one kind once and resolves names to IDs from that list.
+// The zero value is ready to use and is safe for concurrent use.
+// A failed list isn't cached, so the next call retries it.
+type nameResolver struct {
+	mu     sync.Mutex
+	loaded bool
+	items  []namedID
+}
+
+// resolve returns the ID of the object with the given name, calling list only on the first successful lookup.
+func (r *nameResolver) resolve(kind, name string, list func() ([]namedID, error)) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.loaded {
+		items, err := list()
+		if err != nil {
+			return 0, err
+		}
+		log.Printf("[DEBUG] Cached %d %ss for name resolution", len(items), kind)
+		r.items, r.loaded = items, true
+	}
+
+	return findIDByName(kind, r.items, name)
+}
+
+// findIDByName searches for an object with the specified name.
+// An exact match wins over a case-insensitive one; several matches of the same kind are reported as ambiguous.
+func findIDByName(kind string, items []namedID, name string) (int, error) {
+	var exact, folded []namedID
+	for _, item := range items {
+		switch {
+		case item.Name == name:
+			exact = append(exact, item)
+		case strings.EqualFold(item.Name, name):
+			folded = append(folded, item)
+		}
+	}
+
+	for _, matches := range [][]namedID{exact, folded} {
+		switch len(matches) {
+		case 0:
+			continue
+		case 1:
+			return matches[0].ID, nil
+		default:
+			ids := make([]string, 0, len(matches))
+			for _, m := range matches {
+				ids = append(ids, fmt.Sprintf("%d (%s)", m.ID, m.Name))
+			}
+			return 0, fmt.Errorf("%s name %s is ambiguous, it matches %s; use %s_id instead", kind, name, strings.Join(ids, ", "), kind)
+		}
+	}
+
+	names := make([]string, 0, len(items))
+	for _, item := range items {
+		names = append(names, item.Name)
+	}
+
+	return 0, fmt.Errorf("%s with name %s not found, available: %s", kind, name, strings.Join(names, ", "))
+}
+
+// resolveProject returns a valid project ID, resolving projectName through the cached project list of the provider.
+func (c *Config) resolveProject(projectID int, projectName string) (int, error) {
+	if projectID != 0 {
+		return projectID, nil
+	}
+
+	return c.projects.resolve("project", projectName, func() ([]namedID, error) {
+		return listProjects(c.Provider)
+	})
+}
+
+// resolveRegion returns a valid region ID, resolving regionName through the cached region list of the provider.
+func (c *Config) resolveRegion(regionID int, regionName string) (int, error) {
+	if regionID != 0 {
+		return regionID, nil
+	}
+
+	return c.regions.resolve("region", regionName, func() ([]namedID, error) {
+		return listRegions(c.Provider)
+	})
+}
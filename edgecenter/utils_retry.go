@@ -0,0 +1,144 @@
+package edgecenter
+
+import (
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	retryMinWait  = time.Second
+	retryMaxShift = 16
+)
+
+// roundTripperFunc adapts a function to http.RoundTripper.
+type roundTripperFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// retryTransport retries requests rejected by throttling or by a temporarily unavailable API.
+// Requests which may create something, e.g. task-creating POSTs, are retried only when the API
+// confirms that they weren't processed: on 429 Too Many Requests or when a Retry-After header is sent.
+type retryTransport struct {
+	next       http.RoundTripper
+	maxRetries int
+	maxWait    time.Duration
+}
+
+// newRetryTransport wraps next, or http.DefaultTransport when next is nil, with retries.
+func newRetryTransport(next http.RoundTripper, maxRetries int, maxWait time.Duration) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	if maxRetries <= 0 {
+		return next
+	}
+
+	return &retryTransport{next: next, maxRetries: maxRetries, maxWait: maxWait}
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		resp, err := t.next.RoundTrip(req)
+		if attempt >= t.maxRetries || !shouldRetry(req, resp, err) {
+			return resp, err //nolint: wrapcheck
+		}
+		if req.Body != nil && req.GetBody == nil {
+			return resp, err //nolint: wrapcheck
+		}
+
+		wait := t.backoff(attempt, resp)
+		if resp != nil {
+			log.Printf("[DEBUG] %s %s returned %d, retrying in %s", req.Method, req.URL.Path, resp.StatusCode, wait)
+			io.Copy(io.Discard, resp.Body) //nolint: errcheck
+			resp.Body.Close()
+		} else {
+			log.Printf("[DEBUG] %s %s failed: %s, retrying in %s", req.Method, req.URL.Path, err, wait)
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err() //nolint: wrapcheck
+		case <-timer.C:
+		}
+
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err //nolint: wrapcheck
+			}
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+	}
+}
+
+// backoff returns the delay before the next attempt, preferring the Retry-After header of the response.
+func (t *retryTransport) backoff(attempt int, resp *http.Response) time.Duration {
+	wait := t.maxWait
+	if attempt < retryMaxShift {
+		wait = retryMinWait << attempt
+	}
+	if resp != nil {
+		if after, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			wait = after
+		}
+	}
+	if wait > t.maxWait {
+		wait = t.maxWait
+	}
+
+	return wait
+}
+
+// shouldRetry reports whether the request may be sent again after the given outcome.
+func shouldRetry(req *http.Request, resp *http.Response, err error) bool {
+	if req.Context().Err() != nil {
+		return false
+	}
+	if err != nil {
+		return isIdempotent(req.Method)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return isIdempotent(req.Method) || resp.Header.Get("Retry-After") != ""
+	}
+
+	return false
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+
+	return false
+}
+
+// parseRetryAfter parses the Retry-After header given either in seconds or as an HTTP date.
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		if wait := time.Until(at); wait > 0 {
+			return wait, true
+		}
+		return 0, true
+	}
+
+	return 0, false
+}
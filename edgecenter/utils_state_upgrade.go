@@ -0,0 +1,175 @@
+package edgecenter
+
+import (
+	"context"
+	"log"
+	"sort"
+)
+
+// upgradeComputeStateV0 moves the deprecated attributes of instance and baremetal state to their replacements:
+// userdata to user_data, a single name_templates item to name_template and metadata to metadata_map.
+// Values of the new attributes are kept when both are set.
+func upgradeComputeStateV0(_ context.Context, rawState map[string]interface{}, _ interface{}) (map[string]interface{}, error) {
+	if rawState == nil {
+		return rawState, nil
+	}
+
+	if userData, ok := rawState["userdata"].(string); ok && userData != "" {
+		if current, _ := rawState["user_data"].(string); current == "" {
+			rawState["user_data"] = userData
+		}
+		delete(rawState, "userdata")
+	}
+
+	if templates, ok := rawState["name_templates"].([]interface{}); ok && len(templates) == 1 {
+		if current, _ := rawState["name_template"].(string); current == "" {
+			rawState["name_template"] = templates[0]
+		}
+		delete(rawState, "name_templates")
+	}
+
+	if items, ok := rawState["metadata"].([]interface{}); ok && len(items) > 0 {
+		metadataMap, _ := rawState["metadata_map"].(map[string]interface{})
+		if metadataMap == nil {
+			metadataMap = make(map[string]interface{}, len(items))
+		}
+		for _, raw := range items {
+			item, _ := raw.(map[string]interface{})
+			key, _ := item["key"].(string)
+			if _, exists := metadataMap[key]; key != "" && !exists {
+				metadataMap[key] = item["value"]
+			}
+		}
+		rawState["metadata_map"] = metadataMap
+		delete(rawState, "metadata")
+	}
+
+	log.Printf("[DEBUG] Upgraded deprecated attributes of %v to state version 1", rawState["id"])
+
+	return rawState, nil
+}
+
+// cdnOptionUpgrade is a deprecated CDN option together with the option its schema names as the replacement.
+type cdnOptionUpgrade struct {
+	legacy      string
+	replacement string
+	convert     func(opt map[string]interface{}) map[string]interface{}
+}
+
+// cdnOptionUpgrades lists the deprecated options of CDN resources and rules moved by the state upgrade.
+var cdnOptionUpgrades = []cdnOptionUpgrade{
+	{legacy: "static_headers", replacement: "static_response_headers", convert: staticHeadersToResponseHeaders},
+	{legacy: "cache_http_headers", replacement: "response_headers_hiding_policy", convert: cacheHTTPHeadersToHidingPolicy},
+}
+
+// upgradeCDNOptionsStateV0 moves the deprecated static_headers and cache_http_headers options of CDN resource
+// and rule state to static_response_headers and response_headers_hiding_policy, unless those are set already.
+func upgradeCDNOptionsStateV0(_ context.Context, rawState map[string]interface{}, _ interface{}) (map[string]interface{}, error) {
+	if rawState == nil {
+		return rawState, nil
+	}
+
+	optionsList, _ := rawState["options"].([]interface{})
+	if len(optionsList) == 0 {
+		return rawState, nil
+	}
+	options, _ := optionsList[0].(map[string]interface{})
+	if options == nil {
+		return rawState, nil
+	}
+
+	for _, upgrade := range cdnOptionUpgrades {
+		if opt, ok := firstStateItem(options[upgrade.legacy]); ok {
+			if _, replaced := firstStateItem(options[upgrade.replacement]); !replaced {
+				options[upgrade.replacement] = []interface{}{upgrade.convert(opt)}
+			}
+			delete(options, upgrade.legacy)
+		}
+	}
+
+	return rawState, nil
+}
+
+// keepUpgradedCDNOptions protects the options moved by the state upgrade from the next refresh.
+// The API keeps returning an option under the deprecated name it was last sent with, so until the next update
+// sends the replacement, a resource or rule upgraded from static_headers or cache_http_headers still reads
+// them back instead of static_response_headers or response_headers_hiding_policy. Such a legacy option is
+// reported as its replacement when the state holds only the replacement and the API only the legacy option.
+// stateOptions and apiOptions are the options lists of the state and of the API response.
+func keepUpgradedCDNOptions(stateOptions, apiOptions []interface{}) {
+	if len(stateOptions) == 0 || len(apiOptions) == 0 {
+		return
+	}
+	state, _ := stateOptions[0].(map[string]interface{})
+	api, _ := apiOptions[0].(map[string]interface{})
+	if state == nil || api == nil {
+		return
+	}
+
+	for _, upgrade := range cdnOptionUpgrades {
+		_, stateLegacy := firstStateItem(state[upgrade.legacy])
+		_, stateReplaced := firstStateItem(state[upgrade.replacement])
+		opt, apiLegacy := firstStateItem(api[upgrade.legacy])
+		_, apiReplaced := firstStateItem(api[upgrade.replacement])
+		if stateLegacy || !stateReplaced || !apiLegacy || apiReplaced {
+			continue
+		}
+
+		api[upgrade.replacement] = []interface{}{upgrade.convert(opt)}
+		delete(api, upgrade.legacy)
+	}
+}
+
+// cacheHTTPHeadersToHidingPolicy converts a cache_http_headers option, the headers passed on to clients,
+// into a response_headers_hiding_policy option which hides all headers except those.
+func cacheHTTPHeadersToHidingPolicy(opt map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"enabled":  opt["enabled"],
+		"mode":     "hide",
+		"excepted": opt["value"],
+	}
+}
+
+// staticHeadersToResponseHeaders converts a static_headers option, a map of header names to values,
+// into a static_response_headers option with one item per header, ordered by name.
+func staticHeadersToResponseHeaders(opt map[string]interface{}) map[string]interface{} {
+	headers := make(map[string]interface{})
+	switch value := opt["value"].(type) {
+	case map[string]interface{}:
+		headers = value
+	case map[string]string:
+		for name, v := range value {
+			headers[name] = v
+		}
+	}
+
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	items := make([]interface{}, 0, len(headers))
+	for _, name := range names {
+		items = append(items, map[string]interface{}{
+			"name":   name,
+			"value":  []interface{}{headers[name]},
+			"always": false,
+		})
+	}
+
+	return map[string]interface{}{
+		"enabled": opt["enabled"],
+		"value":   items,
+	}
+}
+
+// firstStateItem returns the only item of a MaxItems: 1 block in raw state.
+func firstStateItem(raw interface{}) (map[string]interface{}, bool) {
+	list, _ := raw.([]interface{})
+	if len(list) == 0 {
+		return nil, false
+	}
+	item, ok := list[0].(map[string]interface{})
+
+	return item, ok && item != nil
+}
@@ -0,0 +1,280 @@
+package edgecenter
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	ctyjson "github.com/hashicorp/go-cty/cty/json"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// loadStateV0 reads a state version 0 fixture and checks it decodes with the upgrader type,
+// as the state of a provider release before the upgrade would.
+func loadStateV0(t *testing.T, name string, upgrader schema.StateUpgrader) map[string]interface{} {
+	t.Helper()
+
+	raw, err := os.ReadFile(filepath.Join("testdata", "state_upgrade", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ctyjson.Unmarshal(raw, upgrader.Type); err != nil {
+		t.Fatalf("fixture %s doesn't match the state version 0 schema: %v", name, err)
+	}
+
+	var state map[string]interface{}
+	if err := json.Unmarshal(raw, &state); err != nil {
+		t.Fatal(err)
+	}
+
+	return state
+}
+
+// upgradeStateV0 runs the only upgrader of r on a fixture and checks the result decodes with the current schema.
+func upgradeStateV0(t *testing.T, r *schema.Resource, fixture string) map[string]interface{} {
+	t.Helper()
+
+	if r.SchemaVersion != 1 || len(r.StateUpgraders) != 1 {
+		t.Fatalf("expected schema version 1 with one state upgrader, got %d with %d", r.SchemaVersion, len(r.StateUpgraders))
+	}
+	upgrader := r.StateUpgraders[0]
+
+	state, err := upgrader.Upgrade(context.Background(), loadStateV0(t, fixture, upgrader), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	raw, err := json.Marshal(state)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ctyjson.Unmarshal(raw, r.CoreConfigSchema().ImpliedType()); err != nil {
+		t.Fatalf("upgraded state doesn't match the current schema: %v", err)
+	}
+
+	return state
+}
+
+func TestComputeStateUpgradeV0(t *testing.T) {
+	tests := []struct {
+		resource    *schema.Resource
+		fixture     string
+		userData    interface{}
+		template    string
+		metadataMap map[string]interface{}
+	}{
+		{
+			resource:    resourceInstance(),
+			fixture:     "instance_v0.json",
+			userData:    "I2Nsb3VkLWNvbmZpZwpwYWNrYWdlczogW25naW54XQo=",
+			template:    "web-{ip_octets}",
+			metadataMap: map[string]interface{}{"env": "prod", "team": "platform"},
+		},
+		{
+			resource:    resourceBmInstance(),
+			fixture:     "baremetal_v0.json",
+			userData:    nil,
+			template:    "bm-db-{ip_octets}",
+			metadataMap: map[string]interface{}{"role": "db", "env": "prod"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.fixture, func(t *testing.T) {
+			state := upgradeStateV0(t, tt.resource, tt.fixture)
+
+			for _, key := range []string{"userdata", "name_templates", "metadata"} {
+				if _, ok := state[key]; ok {
+					t.Errorf("deprecated %s is still in the upgraded state", key)
+				}
+			}
+			if state["user_data"] != tt.userData {
+				t.Errorf("user_data = %v, want %v", state["user_data"], tt.userData)
+			}
+			if state["name_template"] != tt.template {
+				t.Errorf("name_template = %v, want %v", state["name_template"], tt.template)
+			}
+			if !reflect.DeepEqual(state["metadata_map"], tt.metadataMap) {
+				t.Errorf("metadata_map = %v, want %v", state["metadata_map"], tt.metadataMap)
+			}
+		})
+	}
+}
+
+func TestCDNOptionsStateUpgradeV0(t *testing.T) {
+	wantResponseHeaders := []interface{}{
+		map[string]interface{}{
+			"enabled": true,
+			"value": []interface{}{
+				map[string]interface{}{"name": "X-Frame-Options", "value": []interface{}{"DENY"}, "always": false},
+				map[string]interface{}{"name": "X-Served-By", "value": []interface{}{"edgecenter"}, "always": false},
+			},
+		},
+	}
+	wantHidingPolicy := []interface{}{
+		map[string]interface{}{
+			"enabled":  true,
+			"mode":     "hide",
+			"excepted": []interface{}{"Content-Type", "Content-Length", "Date", "Server"},
+		},
+	}
+
+	tests := []struct {
+		resource *schema.Resource
+		fixture  string
+	}{
+		{resource: resourceCDNResource(), fixture: "cdn_resource_v0.json"},
+		{resource: resourceCDNRule(), fixture: "cdn_rule_v0.json"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.fixture, func(t *testing.T) {
+			state := upgradeStateV0(t, tt.resource, tt.fixture)
+			options := state["options"].([]interface{})[0].(map[string]interface{})
+
+			for _, key := range []string{"static_headers", "cache_http_headers"} {
+				if _, ok := options[key]; ok {
+					t.Errorf("deprecated %s is still in the upgraded state", key)
+				}
+			}
+			if !reflect.DeepEqual(options["static_response_headers"], wantResponseHeaders) {
+				t.Errorf("static_response_headers = %v, want %v", options["static_response_headers"], wantResponseHeaders)
+			}
+			if !reflect.DeepEqual(options["response_headers_hiding_policy"], wantHidingPolicy) {
+				t.Errorf("response_headers_hiding_policy = %v, want %v", options["response_headers_hiding_policy"], wantHidingPolicy)
+			}
+		})
+	}
+}
+
+func TestCDNOptionsStateUpgradeV0KeepsReplacements(t *testing.T) {
+	hidingPolicy := []interface{}{
+		map[string]interface{}{"enabled": true, "mode": "show", "excepted": []interface{}{"Set-Cookie"}},
+	}
+	responseHeaders := []interface{}{
+		map[string]interface{}{
+			"enabled": true,
+			"value": []interface{}{
+				map[string]interface{}{"name": "X-Served-By", "value": []interface{}{"origin"}, "always": true},
+			},
+		},
+	}
+	rawState := map[string]interface{}{
+		"options": []interface{}{
+			map[string]interface{}{
+				"cache_http_headers":             []interface{}{map[string]interface{}{"enabled": true, "value": []interface{}{"Date"}}},
+				"response_headers_hiding_policy": hidingPolicy,
+				"static_headers":                 []interface{}{map[string]interface{}{"enabled": true, "value": map[string]interface{}{"X-Served-By": "edgecenter"}}},
+				"static_response_headers":        responseHeaders,
+			},
+		},
+	}
+
+	state, err := upgradeCDNOptionsStateV0(context.Background(), rawState, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]interface{}{
+		"response_headers_hiding_policy": hidingPolicy,
+		"static_response_headers":        responseHeaders,
+	}
+	if options := state["options"].([]interface{})[0]; !reflect.DeepEqual(options, want) {
+		t.Errorf("options = %v, want %v", options, want)
+	}
+}
+
+func TestKeepUpgradedCDNOptions(t *testing.T) {
+	staticHeaders := []interface{}{
+		map[string]interface{}{"enabled": true, "value": map[string]string{"X-Served-By": "edgecenter"}},
+	}
+	responseHeaders := []interface{}{
+		map[string]interface{}{
+			"enabled": true,
+			"value": []interface{}{
+				map[string]interface{}{"name": "X-Served-By", "value": []interface{}{"edgecenter"}, "always": false},
+			},
+		},
+	}
+	// the API returns the option values as the SDK decodes them
+	cacheHTTPHeaders := []interface{}{
+		map[string]interface{}{"enabled": true, "value": []string{"Date", "Server"}},
+	}
+	hidingPolicy := []interface{}{
+		map[string]interface{}{"enabled": true, "mode": "hide", "excepted": []string{"Date", "Server"}},
+	}
+
+	tests := []struct {
+		name  string
+		state map[string]interface{}
+		api   map[string]interface{}
+		want  map[string]interface{}
+	}{
+		{
+			name:  "upgraded state keeps the replacement",
+			state: map[string]interface{}{"static_headers": []interface{}{}, "static_response_headers": responseHeaders},
+			api:   map[string]interface{}{"static_headers": staticHeaders, "static_response_headers": []interface{}{}},
+			want:  map[string]interface{}{"static_response_headers": responseHeaders},
+		},
+		{
+			name:  "legacy option in config is reported as is",
+			state: map[string]interface{}{"static_headers": staticHeaders, "static_response_headers": []interface{}{}},
+			api:   map[string]interface{}{"static_headers": staticHeaders, "static_response_headers": []interface{}{}},
+			want:  map[string]interface{}{"static_headers": staticHeaders, "static_response_headers": []interface{}{}},
+		},
+		{
+			name:  "option removed out of band is reported",
+			state: map[string]interface{}{"static_headers": []interface{}{}, "static_response_headers": responseHeaders},
+			api:   map[string]interface{}{"static_headers": []interface{}{}, "static_response_headers": []interface{}{}},
+			want:  map[string]interface{}{"static_headers": []interface{}{}, "static_response_headers": []interface{}{}},
+		},
+		{
+			name:  "replacement from the API wins",
+			state: map[string]interface{}{"static_headers": []interface{}{}, "static_response_headers": responseHeaders},
+			api:   map[string]interface{}{"static_headers": staticHeaders, "static_response_headers": responseHeaders},
+			want:  map[string]interface{}{"static_headers": staticHeaders, "static_response_headers": responseHeaders},
+		},
+		{
+			name:  "upgraded cache http headers keep the hiding policy",
+			state: map[string]interface{}{"cache_http_headers": []interface{}{}, "response_headers_hiding_policy": hidingPolicy},
+			api:   map[string]interface{}{"cache_http_headers": cacheHTTPHeaders},
+			want:  map[string]interface{}{"response_headers_hiding_policy": hidingPolicy},
+		},
+		{
+			name:  "legacy cache http headers in config are reported as is",
+			state: map[string]interface{}{"cache_http_headers": cacheHTTPHeaders},
+			api:   map[string]interface{}{"cache_http_headers": cacheHTTPHeaders},
+			want:  map[string]interface{}{"cache_http_headers": cacheHTTPHeaders},
+		},
+		{
+			name:  "hiding policy from the API wins",
+			state: map[string]interface{}{"response_headers_hiding_policy": hidingPolicy},
+			api:   map[string]interface{}{"cache_http_headers": cacheHTTPHeaders, "response_headers_hiding_policy": hidingPolicy},
+			want:  map[string]interface{}{"cache_http_headers": cacheHTTPHeaders, "response_headers_hiding_policy": hidingPolicy},
+		},
+		{
+			name: "each option is kept on its own",
+			state: map[string]interface{}{
+				"static_headers":                 staticHeaders,
+				"response_headers_hiding_policy": hidingPolicy,
+			},
+			api: map[string]interface{}{"static_headers": staticHeaders, "cache_http_headers": cacheHTTPHeaders},
+			want: map[string]interface{}{
+				"static_headers":                 staticHeaders,
+				"response_headers_hiding_policy": hidingPolicy,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			apiOptions := []interface{}{tt.api}
+			keepUpgradedCDNOptions([]interface{}{tt.state}, apiOptions)
+			if !reflect.DeepEqual(apiOptions[0], tt.want) {
+				t.Errorf("options = %v, want %v", apiOptions[0], tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package edgecenter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	edgecloud "github.com/Edge-Center/edgecentercloud-go"
+	"github.com/Edge-Center/edgecentercloud-go/edgecenter/task/v1/tasks"
+)
+
+const (
+	taskPollMinInterval = time.Second
+	taskPollMaxInterval = 15 * time.Second
+)
+
+// waitTask polls the task with an exponential backoff until it finishes.
+// It stops as soon as ctx is done or timeout elapses, reporting the task ID and its last known state.
+func waitTask(ctx context.Context, client *edgecloud.ServiceClient, taskID tasks.TaskID, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	interval := taskPollMinInterval
+	for {
+		task, err := tasks.Get(client, string(taskID)).Extract()
+		if err != nil {
+			return fmt.Errorf("cannot get task with ID: %s. Error: %w", taskID, err)
+		}
+
+		switch task.State {
+		case tasks.TaskStateFinished:
+			return nil
+		case tasks.TaskStateError:
+			reason := "unknown error"
+			if task.Error != nil {
+				reason = *task.Error
+			}
+			return fmt.Errorf("task %s failed: %s", taskID, reason)
+		}
+		log.Printf("[DEBUG] Task %s is in state %s, next check in %s", taskID, task.State, interval)
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return fmt.Errorf("timeout while waiting for task %s, last known state %s: %w", taskID, task.State, ctx.Err())
+			}
+			return fmt.Errorf("waiting for task %s was cancelled, last known state %s: %w", taskID, task.State, ctx.Err())
+		case <-timer.C:
+		}
+
+		interval *= 2
+		if interval > taskPollMaxInterval {
+			interval = taskPollMaxInterval
+		}
+	}
+}
+
+// waitTaskAndReturnResult waits for the task like waitTask and then returns the result of retrieveResult.
+// It is the context-aware counterpart of tasks.WaitTaskAndReturnResult.
+func waitTaskAndReturnResult(
+	ctx context.Context, client *edgecloud.ServiceClient, taskID tasks.TaskID, timeout time.Duration,
+	retrieveResult func(task tasks.TaskID) (interface{}, error),
+) (interface{}, error) {
+	if err := waitTask(ctx, client, taskID, timeout); err != nil {
+		return nil, err
+	}
+
+	return retrieveResult(taskID)
+}
+
+// waitTaskAndProcessResult waits for the task like waitTask and then runs checkResult.
+// It is the context-aware counterpart of tasks.WaitTaskAndProcessResult.
+func waitTaskAndProcessResult(
+	ctx context.Context, client *edgecloud.ServiceClient, taskID tasks.TaskID, timeout time.Duration,
+	checkResult func(task tasks.TaskID) error,
+) error {
+	if err := waitTask(ctx, client, taskID, timeout); err != nil {
+		return err
+	}
+
+	return checkResult(taskID)
+}
@@ -0,0 +1,138 @@
+package edgecenter
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	edgecloud "github.com/Edge-Center/edgecentercloud-go"
+)
+
+// tokenRefreshMargin is how long before its expiry an access token is already refreshed.
+const tokenRefreshMargin = time.Minute
+
+// tokenSource hands out the access token of the cloud provider client of the user_name/password flow,
+// re-authenticating the client when the token is about to expire or is rejected.
+// It is shared by the CDN, DNS and storage clients, so a token is refreshed once for all of them.
+// The cloud client isn't routed through it: the source is built on that client, whose SDK already
+// re-authenticates on 401 under the same lock, and sends the re-authentication requests with its own transport.
+type tokenSource struct {
+	provider *edgecloud.ProviderClient
+	mu       sync.Mutex
+}
+
+// Token returns a valid access token, refreshing it first if it expires within tokenRefreshMargin.
+func (s *tokenSource) Token() (string, error) {
+	token := s.provider.AccessToken()
+	if expiry, ok := tokenExpiry(token); ok && time.Until(expiry) < tokenRefreshMargin {
+		if err := s.refresh(token); err != nil {
+			return "", err
+		}
+		token = s.provider.AccessToken()
+	}
+
+	return token, nil
+}
+
+// refresh re-authenticates the provider client unless another request has already replaced staleToken.
+func (s *tokenSource) refresh(staleToken string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.provider.AccessToken() != staleToken {
+		return nil
+	}
+	log.Println("[DEBUG] Refreshing the access token")
+	if err := s.provider.Reauthenticate(staleToken); err != nil {
+		return fmt.Errorf("cannot refresh the access token: %w", err)
+	}
+
+	return nil
+}
+
+// tokenExpiry returns the expiry time of a JWT access token, if it has one.
+func tokenExpiry(token string) (time.Time, bool) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return time.Time{}, false
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return time.Time{}, false
+	}
+	var claims struct {
+		Exp int64 `json:"exp"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil || claims.Exp == 0 {
+		return time.Time{}, false
+	}
+
+	return time.Unix(claims.Exp, 0), true
+}
+
+// tokenAuthTransport sets the bearer token of every request from a shared tokenSource.
+// A request rejected with 401 Unauthorized is sent once more after the token is refreshed.
+type tokenAuthTransport struct {
+	next   http.RoundTripper
+	tokens *tokenSource
+}
+
+// newTokenAuthTransport wraps next, or http.DefaultTransport when next is nil, with tokens.
+// Without a token source, e.g. with a permanent API token, next is returned as is.
+func newTokenAuthTransport(next http.RoundTripper, tokens *tokenSource) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	if tokens == nil {
+		return next
+	}
+
+	return &tokenAuthTransport{next: next, tokens: tokens}
+}
+
+func (t *tokenAuthTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	token, err := t.tokens.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := t.next.RoundTrip(withBearerToken(req, token))
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err //nolint: wrapcheck
+	}
+	if req.Body != nil && req.GetBody == nil {
+		return resp, nil
+	}
+
+	if err := t.tokens.refresh(token); err != nil {
+		log.Printf("[WARN] %s %s was rejected with 401: %s", req.Method, req.URL.Path, err)
+		return resp, nil
+	}
+	io.Copy(io.Discard, resp.Body) //nolint: errcheck
+	resp.Body.Close()
+
+	retry := withBearerToken(req, t.tokens.provider.AccessToken())
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err //nolint: wrapcheck
+		}
+		retry.Body = body
+	}
+
+	return t.next.RoundTrip(retry) //nolint: wrapcheck
+}
+
+// withBearerToken returns a copy of req authorized with token, as a RoundTripper mustn't modify its request.
+func withBearerToken(req *http.Request, token string) *http.Request {
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	return req
+}
@@ -0,0 +1,147 @@
+package edgecenter
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	edgecloud "github.com/Edge-Center/edgecentercloud-go"
+)
+
+// testJWT returns an unsigned JWT which expires at exp.
+func testJWT(exp time.Time, n int32) string {
+	encode := base64.RawURLEncoding.EncodeToString
+	payload := fmt.Sprintf(`{"exp":%d,"n":%d}`, exp.Unix(), n)
+
+	return encode([]byte(`{"alg":"none"}`)) + "." + encode([]byte(payload)) + ".sig"
+}
+
+// newTestTokenSource returns a token source over a provider client holding token,
+// whose re-authentication issues tokens valid for an hour and is counted in reauths.
+func newTestTokenSource(token string, reauths *int32) *tokenSource {
+	provider := &edgecloud.ProviderClient{}
+	provider.UseTokenLock()
+	provider.CopyTokensFrom(&edgecloud.ProviderClient{AccessTokenID: token})
+	provider.ReauthFunc = func() error {
+		n := atomic.AddInt32(reauths, 1)
+		// widen the window in which concurrent callers may also try to refresh
+		time.Sleep(10 * time.Millisecond)
+		provider.CopyTokensFrom(&edgecloud.ProviderClient{AccessTokenID: testJWT(time.Now().Add(time.Hour), n)})
+		return nil
+	}
+
+	return &tokenSource{provider: provider}
+}
+
+func TestTokenSourceRefreshesOnExpiry(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		token       string
+		wantReauths int32
+	}{
+		{name: "valid token", token: testJWT(time.Now().Add(time.Hour), 0), wantReauths: 0},
+		{name: "token expiring within the margin", token: testJWT(time.Now().Add(tokenRefreshMargin/2), 0), wantReauths: 1},
+		{name: "expired token", token: testJWT(time.Now().Add(-time.Hour), 0), wantReauths: 1},
+		{name: "opaque token", token: "not-a-jwt", wantReauths: 0},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var reauths int32
+			tokens := newTestTokenSource(tt.token, &reauths)
+
+			got, err := tokens.Token()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if reauths != tt.wantReauths {
+				t.Errorf("Token() re-authenticated %d times, want %d", reauths, tt.wantReauths)
+			}
+			if (got != tt.token) != (tt.wantReauths > 0) {
+				t.Errorf("Token() = %s, refreshed: %t", got, got != tt.token)
+			}
+		})
+	}
+}
+
+func TestTokenSourceRefreshesOnceConcurrently(t *testing.T) {
+	t.Parallel()
+	var reauths int32
+	tokens := newTestTokenSource(testJWT(time.Now().Add(time.Second), 0), &reauths)
+
+	var wg sync.WaitGroup
+	results := make([]string, 20)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			token, err := tokens.Token()
+			if err != nil {
+				t.Error(err)
+			}
+			results[i] = token
+		}(i)
+	}
+	wg.Wait()
+
+	if reauths != 1 {
+		t.Errorf("concurrent Token() calls re-authenticated %d times, want 1", reauths)
+	}
+	for _, token := range results {
+		if token != results[0] {
+			t.Errorf("concurrent Token() calls got different tokens: %s and %s", token, results[0])
+		}
+	}
+}
+
+func TestTokenAuthTransportRetriesUnauthorizedOnce(t *testing.T) {
+	t.Parallel()
+	var reauths int32
+	// the opaque token has no expiry, so only the 401 of the server, which revoked it, makes it refresh
+	tokens := newTestTokenSource("stale-token", &reauths)
+
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		if r.Header.Get("Authorization") == "Bearer stale-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(server.Close)
+
+	client := &http.Client{Transport: newTokenAuthTransport(nil, tokens)}
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := client.Get(server.URL)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			resp.Body.Close()
+			if resp.StatusCode != http.StatusNoContent {
+				t.Errorf("got status %d, want %d", resp.StatusCode, http.StatusNoContent)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if reauths != 1 {
+		t.Errorf("rejected requests re-authenticated %d times, want 1", reauths)
+	}
+	if requests > 20 {
+		t.Errorf("the server got %d requests, want at most one retry per request", requests)
+	}
+}
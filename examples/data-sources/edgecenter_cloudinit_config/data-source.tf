@@ -0,0 +1,43 @@
+provider "edgecenter" {
+  permanent_api_token = "251$d3361.............1b35f26d8"
+}
+
+data "edgecenter_cloudinit_config" "web" {
+  gzip          = true
+  base64_encode = true
+
+  part {
+    content_type = "text/cloud-config"
+    filename     = "packages.cfg"
+    content      = <<-EOT
+      #cloud-config
+      packages:
+        - nginx
+    EOT
+  }
+
+  part {
+    content_type = "text/x-shellscript"
+    filename     = "start.sh"
+    content      = "#!/bin/sh\nsystemctl enable --now nginx"
+  }
+}
+
+resource "edgecenter_instance" "web" {
+  name       = "web"
+  flavor_id  = "g1-standard-2-4"
+  user_data  = data.edgecenter_cloudinit_config.web.rendered
+  region_id  = 1
+  project_id = 1
+
+  volume {
+    source     = "image"
+    image_id   = "f4ce3d30-e29c-4cfd-811f-46f383b6081f"
+    size       = 5
+    boot_index = 0
+  }
+
+  interface {
+    type = "external"
+  }
+}
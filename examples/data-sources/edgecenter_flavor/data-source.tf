@@ -0,0 +1,26 @@
+provider "edgecenter" {
+  permanent_api_token = "251$d3361.............1b35f26d8"
+}
+
+data "edgecenter_project" "pr" {
+  name = "test"
+}
+
+data "edgecenter_region" "rg" {
+  name = "ED-10 Preprod"
+}
+
+data "edgecenter_flavor" "gpu" {
+  region_id      = data.edgecenter_region.rg.id
+  project_id     = data.edgecenter_project.pr.id
+  min_vcpus      = 8
+  has_gpu        = true
+  include_prices = true
+  hardware_description = {
+    gpu = "A100"
+  }
+}
+
+output "view" {
+  value = data.edgecenter_flavor.gpu
+}
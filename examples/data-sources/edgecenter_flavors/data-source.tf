@@ -0,0 +1,24 @@
+provider "edgecenter" {
+  permanent_api_token = "251$d3361.............1b35f26d8"
+}
+
+data "edgecenter_project" "pr" {
+  name = "test"
+}
+
+data "edgecenter_region" "rg" {
+  name = "ED-10 Preprod"
+}
+
+data "edgecenter_flavors" "small" {
+  region_id      = data.edgecenter_region.rg.id
+  project_id     = data.edgecenter_project.pr.id
+  max_vcpus      = 4
+  max_ram        = 8192
+  has_gpu        = false
+  include_prices = true
+}
+
+output "view" {
+  value = data.edgecenter_flavors.small.flavors[*].flavor_id
+}
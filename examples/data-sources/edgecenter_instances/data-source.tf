@@ -0,0 +1,28 @@
+provider "edgecenter" {
+  permanent_api_token = "251$d3361.............1b35f26d8"
+}
+
+data "edgecenter_project" "pr" {
+  name = "test"
+}
+
+data "edgecenter_region" "rg" {
+  name = "ED-10 Preprod"
+}
+
+data "edgecenter_instances" "prod" {
+  region_id  = data.edgecenter_region.rg.id
+  project_id = data.edgecenter_project.pr.id
+  name_regex = "^web-"
+  status     = "ACTIVE"
+  metadata_kv = {
+    env = "prod"
+  }
+}
+
+output "view" {
+  value = {
+    for instance in data.edgecenter_instances.prod.instances :
+    instance.name => instance.addresses[*].net[*].addr
+  }
+}
@@ -0,0 +1,31 @@
+provider "edgecenter" {
+  permanent_api_token = "251$d3361.............1b35f26d8"
+}
+
+resource "edgecenter_image" "packer" {
+  name       = "golden-ubuntu"
+  url        = "https://images.example.com/golden-ubuntu-22.04.qcow2"
+  os_distro  = "ubuntu"
+  os_version = "22.04"
+  ssh_key    = "allow"
+  region_id  = 1
+  project_id = 1
+  metadata_map = {
+    build = "42"
+  }
+}
+
+resource "edgecenter_volume" "golden" {
+  name       = "golden"
+  size       = 5
+  image_id   = edgecenter_image.packer.id
+  region_id  = 1
+  project_id = 1
+}
+
+resource "edgecenter_image" "from_volume" {
+  name       = "golden-from-volume"
+  volume_id  = edgecenter_volume.golden.id
+  region_id  = 1
+  project_id = 1
+}
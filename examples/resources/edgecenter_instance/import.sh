@@ -1,2 +1,4 @@
 # import using <project_id>:<region_id>:<instance_id> format
-terraform import edgecenter_instance.instance1 1:6:447d2959-8ae0-4ca0-8d47-9f050a3637d7
\ No newline at end of file
+terraform import edgecenter_instance.instance1 1:6:447d2959-8ae0-4ca0-8d47-9f050a3637d7
+# or using <project_name>:<region_name>:<instance_id> format
+terraform import edgecenter_instance.instance1 my-project:Luxembourg:447d2959-8ae0-4ca0-8d47-9f050a3637d7
\ No newline at end of file
@@ -131,5 +131,62 @@ resource "edgecenter_instance" "v" {
   }
 }
 
+//***
+// another one example with volumes created together with the instance
+//***
+
+resource "edgecenter_instance" "inline_volumes" {
+  project_id = 1
+  region_id  = 1
+  name       = "inline-volumes"
+  flavor_id  = "g1-standard-1-2"
+
+  volume {
+    source     = "image"
+    image_id   = "f4ce3d30-e29c-4cfd-811f-46f383b6081f"
+    size       = 10
+    boot_index = 0
+  }
+
+  volume {
+    source     = "new-volume"
+    name       = "data"
+    type_name  = "standard"
+    size       = 20
+    boot_index = 1
+  }
+
+  interface {
+    type = "external"
+  }
+}
+
+//***
+// another one example with the boot volume created from an application template (marketplace)
+//***
+
+resource "edgecenter_instance" "gitlab" {
+  project_id = 1
+  region_id  = 1
+  name       = "gitlab"
+  flavor_id  = "g1-standard-2-4"
+
+  volume {
+    source         = "apptemplate"
+    apptemplate_id = "gitlab"
+    size           = 20
+    boot_index     = 0
+  }
+
+  configuration {
+    key   = "gitlab_external_url"
+    value = "https://gitlab.example.com"
+  }
+
+  interface {
+    type = "external"
+  }
+}
+
 
 
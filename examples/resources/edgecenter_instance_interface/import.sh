@@ -0,0 +1,4 @@
+# import using <project_id>:<region_id>:<instance_id>:<port_id> format
+terraform import edgecenter_instance_interface.private 1:6:a0a3e1b6-9b1d-4b1c-b3a5-6d7c6f1e2a40:1f0ea5a1-4b8d-4e22-9a36-2a8f4c1bd7e3
+# or using <project_name>:<region_name>:<instance_id>:<port_id> format
+terraform import edgecenter_instance_interface.private my-project:Luxembourg:a0a3e1b6-9b1d-4b1c-b3a5-6d7c6f1e2a40:1f0ea5a1-4b8d-4e22-9a36-2a8f4c1bd7e3
\ No newline at end of file
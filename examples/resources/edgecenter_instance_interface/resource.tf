@@ -0,0 +1,28 @@
+provider "edgecenter" {
+  permanent_api_token = "251$d3361.............1b35f26d8"
+}
+
+resource "edgecenter_network" "private" {
+  name       = "private_network"
+  type       = "vxlan"
+  region_id  = 1
+  project_id = 1
+}
+
+resource "edgecenter_subnet" "private" {
+  name       = "private_subnet"
+  cidr       = "192.168.10.0/24"
+  network_id = edgecenter_network.private.id
+  region_id  = 1
+  project_id = 1
+}
+
+resource "edgecenter_instance_interface" "private" {
+  instance_id     = "a0a3e1b6-9b1d-4b1c-b3a5-6d7c6f1e2a40"
+  type            = "subnet"
+  network_id      = edgecenter_network.private.id
+  subnet_id       = edgecenter_subnet.private.id
+  security_groups = ["d75db0b2-58f1-4a11-88c6-a932bb897310"]
+  region_id       = 1
+  project_id      = 1
+}
@@ -0,0 +1,4 @@
+# import using <project_id>:<sshkey_id> format
+terraform import edgecenter_keypair.kp 1:447d2959-8ae0-4ca0-8d47-9f050a3637d7
+# or using <project_name>:<sshkey_id> format
+terraform import edgecenter_keypair.kp my-project:447d2959-8ae0-4ca0-8d47-9f050a3637d7
\ No newline at end of file
@@ -9,5 +9,20 @@ resource "edgecenter_keypair" "kp" {
 }
 
 output "kp" {
-  value = edgecenter_keypair.kp
+  value     = edgecenter_keypair.kp
+  sensitive = true
+}
+
+// the key is generated by the provider and shared with all users of the project
+resource "edgecenter_keypair" "generated" {
+  project_id        = 1
+  sshkey_name       = "generated"
+  generate          = true
+  algorithm         = "ed25519"
+  shared_in_project = true
+}
+
+output "private_key" {
+  value     = edgecenter_keypair.generated.private_key
+  sensitive = true
 }
@@ -1,2 +1,4 @@
 # import using <project_id>:<region_id>:<lbmember>:<pool_id> format
-terraform import edgecenter_lbmember.lbmember1 1:6:a775dd94-4e9c-4da7-9f0e-ffc9ae34446b:447d2959-8ae0-4ca0-8d47-9f050a3637d7
\ No newline at end of file
+terraform import edgecenter_lbmember.lbmember1 1:6:a775dd94-4e9c-4da7-9f0e-ffc9ae34446b:447d2959-8ae0-4ca0-8d47-9f050a3637d7
+# or using <project_name>:<region_name>:<lbmember>:<pool_id> format
+terraform import edgecenter_lbmember.lbmember1 my-project:Luxembourg:a775dd94-4e9c-4da7-9f0e-ffc9ae34446b:447d2959-8ae0-4ca0-8d47-9f050a3637d7
\ No newline at end of file
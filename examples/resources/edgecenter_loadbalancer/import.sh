@@ -1,2 +1,4 @@
 # import using <project_id>:<region_id>:<loadbalancer_id>:<listener_id> format, listener_id - nested listener id
-terraform import edgecenter_loadbalancer.loadbalancer1 1:6:447d2959-8ae0-4ca0-8d47-9f050a3637d7:a336f28c-fbb0-4256-9545-e905bed9f48f
\ No newline at end of file
+terraform import edgecenter_loadbalancer.loadbalancer1 1:6:447d2959-8ae0-4ca0-8d47-9f050a3637d7:a336f28c-fbb0-4256-9545-e905bed9f48f
+# or using <project_name>:<region_name>:<loadbalancer_id>:<listener_id> format, listener_id - nested listener id
+terraform import edgecenter_loadbalancer.loadbalancer1 my-project:Luxembourg:447d2959-8ae0-4ca0-8d47-9f050a3637d7:a336f28c-fbb0-4256-9545-e905bed9f48f
\ No newline at end of file
@@ -1,2 +1,4 @@
 # import using <project_id>:<region_id>:<reservedfixedip_id> format
-terraform import edgecenter_reservedfixedip.reservedfixedip1 1:6:447d2959-8ae0-4ca0-8d47-9f050a3637d7
\ No newline at end of file
+terraform import edgecenter_reservedfixedip.reservedfixedip1 1:6:447d2959-8ae0-4ca0-8d47-9f050a3637d7
+# or using <project_name>:<region_name>:<reservedfixedip_id> format
+terraform import edgecenter_reservedfixedip.reservedfixedip1 my-project:Luxembourg:447d2959-8ae0-4ca0-8d47-9f050a3637d7
\ No newline at end of file
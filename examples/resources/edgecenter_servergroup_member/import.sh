@@ -0,0 +1,4 @@
+# import using <project_id>:<region_id>:<servergroup_id>:<instance_id> format
+terraform import edgecenter_servergroup_member.worker 1:6:5c3b7e52-0f3e-4b8f-9c44-8ab1d6f4e6a1:a0a3e1b6-9b1d-4b1c-b3a5-6d7c6f1e2a40
+# or using <project_name>:<region_name>:<servergroup_id>:<instance_id> format
+terraform import edgecenter_servergroup_member.worker my-project:Luxembourg:5c3b7e52-0f3e-4b8f-9c44-8ab1d6f4e6a1:a0a3e1b6-9b1d-4b1c-b3a5-6d7c6f1e2a40
\ No newline at end of file
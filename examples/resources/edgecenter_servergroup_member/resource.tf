@@ -0,0 +1,17 @@
+provider "edgecenter" {
+  permanent_api_token = "251$d3361.............1b35f26d8"
+}
+
+resource "edgecenter_servergroup" "workers" {
+  name       = "workers"
+  policy     = "anti-affinity"
+  region_id  = 1
+  project_id = 1
+}
+
+resource "edgecenter_servergroup_member" "worker" {
+  servergroup_id = edgecenter_servergroup.workers.id
+  instance_id    = "a0a3e1b6-9b1d-4b1c-b3a5-6d7c6f1e2a40"
+  region_id      = 1
+  project_id     = 1
+}
@@ -0,0 +1,4 @@
+# import using <project_id>:<region_id>:<instance_id>:<volume_id> format
+terraform import edgecenter_volume_attachment.data 1:6:a0a3e1b6-9b1d-4b1c-b3a5-6d7c6f1e2a40:447d2959-8ae0-4ca0-8d47-9f050a3637d7
+# or using <project_name>:<region_name>:<instance_id>:<volume_id> format
+terraform import edgecenter_volume_attachment.data my-project:Luxembourg:a0a3e1b6-9b1d-4b1c-b3a5-6d7c6f1e2a40:447d2959-8ae0-4ca0-8d47-9f050a3637d7
\ No newline at end of file
@@ -0,0 +1,19 @@
+provider "edgecenter" {
+  permanent_api_token = "251$d3361.............1b35f26d8"
+}
+
+resource "edgecenter_volume" "data" {
+  name       = "data volume"
+  type_name  = "standard"
+  size       = 10
+  region_id  = 1
+  project_id = 1
+}
+
+resource "edgecenter_volume_attachment" "data" {
+  instance_id    = "a0a3e1b6-9b1d-4b1c-b3a5-6d7c6f1e2a40"
+  volume_id      = edgecenter_volume.data.id
+  attachment_tag = "data"
+  region_id      = 1
+  project_id     = 1
+}